@@ -0,0 +1,143 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// ErrNoMemoryType is returned when no memory type of the GPU matches a
+// resource's requirements and the requested property flags.
+var ErrNoMemoryType = errors.New("no suitable memory type")
+
+// BufferInfo is a buffer created by CreateBuffer together with the memory
+// bound to it.
+type BufferInfo struct {
+	Buffer vk.Buffer
+	Memory vk.DeviceMemory
+	Size   vk.DeviceSize
+}
+
+// CreateBuffer creates a buffer of size bytes for usage, allocates memory
+// with memProps for it and binds it. Release both with Destroy.
+func CreateBuffer(v *VulkanDeviceInfo, size vk.DeviceSize, usage vk.BufferUsageFlags, memProps vk.MemoryPropertyFlags) (*BufferInfo, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return nil, ErrNoDevice
+	}
+	createInfo := &vk.BufferCreateInfo{
+		SType:       vk.StructureTypeBufferCreateInfo,
+		Size:        size,
+		Usage:       usage,
+		SharingMode: vk.SharingModeExclusive,
+	}
+	var buffer vk.Buffer
+	if err := vk.Error(vk.CreateBuffer(v.device, createInfo, v.allocator, &buffer)); err != nil {
+		err = fmt.Errorf("vkCreateBuffer failed with %s", err)
+		return nil, err
+	}
+
+	var requirements vk.MemoryRequirements
+	vk.GetBufferMemoryRequirements(v.device, buffer, &requirements)
+	requirements.Deref()
+	memory, err := allocateMemory(v, requirements, memProps)
+	if err != nil {
+		vk.DestroyBuffer(v.device, buffer, v.allocator)
+		return nil, err
+	}
+	if err := vk.Error(vk.BindBufferMemory(v.device, buffer, memory, 0)); err != nil {
+		vk.FreeMemory(v.device, memory, v.allocator)
+		vk.DestroyBuffer(v.device, buffer, v.allocator)
+		err = fmt.Errorf("vkBindBufferMemory failed with %s", err)
+		return nil, err
+	}
+	return &BufferInfo{
+		Buffer: buffer,
+		Memory: memory,
+		Size:   size,
+	}, nil
+}
+
+// Map maps the whole buffer into host memory. It needs memory created with
+// vk.MemoryPropertyHostVisibleBit. Release the mapping with Unmap.
+func (b *BufferInfo) Map(v *VulkanDeviceInfo) (unsafe.Pointer, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return nil, ErrNoDevice
+	}
+	var data unsafe.Pointer
+	if err := vk.Error(vk.MapMemory(v.device, b.Memory, 0, vk.DeviceSize(vk.WholeSize), 0, &data)); err != nil {
+		err = fmt.Errorf("vkMapMemory failed with %s", err)
+		return nil, err
+	}
+	return data, nil
+}
+
+// Unmap releases the mapping made by Map.
+func (b *BufferInfo) Unmap(v *VulkanDeviceInfo) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return
+	}
+	vk.UnmapMemory(v.device, b.Memory)
+}
+
+// Destroy destroys the buffer and frees its memory. Calling it again is a
+// no-op. The device must not be using the buffer.
+func (b *BufferInfo) Destroy(v *VulkanDeviceInfo) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if b == nil || v.device == nil {
+		return
+	}
+	if b.Buffer != vk.NullBuffer {
+		vk.DestroyBuffer(v.device, b.Buffer, v.allocator)
+		b.Buffer = vk.NullBuffer
+	}
+	if b.Memory != vk.NullDeviceMemory {
+		vk.FreeMemory(v.device, b.Memory, v.allocator)
+		b.Memory = vk.NullDeviceMemory
+	}
+}
+
+// allocateMemory allocates memory meeting requirements from a memory type
+// with props on the selected GPU.
+func allocateMemory(v *VulkanDeviceInfo, requirements vk.MemoryRequirements, props vk.MemoryPropertyFlags) (vk.DeviceMemory, error) {
+	memoryTypeIndex, err := findMemoryType(v.gpuDevices[v.gpuIndex], requirements.MemoryTypeBits, props)
+	if err != nil {
+		return vk.NullDeviceMemory, err
+	}
+	allocateInfo := &vk.MemoryAllocateInfo{
+		SType:           vk.StructureTypeMemoryAllocateInfo,
+		AllocationSize:  requirements.Size,
+		MemoryTypeIndex: memoryTypeIndex,
+	}
+	var memory vk.DeviceMemory
+	if err := vk.Error(vk.AllocateMemory(v.device, allocateInfo, v.allocator, &memory)); err != nil {
+		err = fmt.Errorf("vkAllocateMemory failed with %s", err)
+		return vk.NullDeviceMemory, err
+	}
+	return memory, nil
+}
+
+// findMemoryType returns the index of the first memory type of gpu that is
+// set in typeFilter, as in vk.MemoryRequirements.MemoryTypeBits, and has
+// all of props.
+func findMemoryType(gpu vk.PhysicalDevice, typeFilter uint32, props vk.MemoryPropertyFlags) (uint32, error) {
+	memoryProperties := GetMemoryProperties(gpu)
+	for i := uint32(0); i < memoryProperties.MemoryTypeCount; i++ {
+		if typeFilter&(1<<i) == 0 {
+			continue
+		}
+		if memoryProperties.MemoryTypes[i].PropertyFlags&props == props {
+			return i, nil
+		}
+	}
+	err := fmt.Errorf("%w: type filter %#x, properties %s", ErrNoMemoryType, typeFilter, memoryPropertyFlags(props))
+	return 0, err
+}
@@ -0,0 +1,76 @@
+package vulkandevice
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// chainHeader is the sType and pNext every extensible Vulkan structure
+// starts with. Structures the vulkan-go bindings don't declare, or declare
+// with Go-only fields, are declared in Go starting with a chainHeader so
+// they share the C layout.
+type chainHeader struct {
+	SType vk.StructureType
+	PNext unsafe.Pointer
+}
+
+// structChain links Vulkan structures through pNext for queries such as
+// vkGetPhysicalDeviceFeatures2. cgo forbids passing C a Go pointer to
+// memory that holds other Go pointers, so the structures are copied into C
+// memory while linked and copied back by read.
+type structChain struct {
+	links []chainLink
+}
+
+type chainLink struct {
+	value unsafe.Pointer
+	size  uintptr
+	c     unsafe.Pointer
+}
+
+// add appends the structure at value, of the given size, to the chain.
+// The structure must start with a chainHeader and hold no Go pointers.
+func (c *structChain) add(value unsafe.Pointer, size uintptr) {
+	c.links = append(c.links, chainLink{value: value, size: size})
+}
+
+// link copies the structures into C memory, links them in the order they
+// were added and returns the head of the chain. free must be called once
+// the chain is no longer used.
+func (c *structChain) link() unsafe.Pointer {
+	for i := range c.links {
+		l := &c.links[i]
+		l.c = C.calloc(1, C.size_t(l.size))
+		copy(unsafe.Slice((*byte)(l.c), l.size), unsafe.Slice((*byte)(l.value), l.size))
+	}
+	for i := 0; i+1 < len(c.links); i++ {
+		(*chainHeader)(c.links[i].c).PNext = c.links[i+1].c
+	}
+	if len(c.links) == 0 {
+		return nil
+	}
+	(*chainHeader)(c.links[len(c.links)-1].c).PNext = nil
+	return c.links[0].c
+}
+
+// read copies the structures back from C memory, leaving pNext nil.
+func (c *structChain) read() {
+	for _, l := range c.links {
+		copy(unsafe.Slice((*byte)(l.value), l.size), unsafe.Slice((*byte)(l.c), l.size))
+		(*chainHeader)(l.value).PNext = nil
+	}
+}
+
+// free releases the C memory allocated by link.
+func (c *structChain) free() {
+	for i := range c.links {
+		C.free(c.links[i].c)
+		c.links[i].c = nil
+	}
+}
@@ -0,0 +1,472 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Buhrietoe/vulkandevice"
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// Exit codes returned by the command.
+const (
+	exitOK        = 0
+	exitError     = 1
+	exitNoDevices = 3
+	exitLoader    = 5
+
+	// exitUsage is returned for unknown flags, subcommands and arguments.
+	exitUsage = 2
+
+	// -diff exits with exitDiffFound if the reports differ, and with
+	// exitDiffError on any error, so it can gate CI.
+	exitDiffFound = 1
+	exitDiffError = 2
+
+	// exitRequirementFailed is returned when a -require-* check fails.
+	exitRequirementFailed = 4
+)
+
+// validationLayerName is the layer enabled by -validate.
+const validationLayerName = "VK_LAYER_KHRONOS_validation"
+
+// deviceTypes maps the -device-type values to device types. "any" leaves
+// the choice to the other flags.
+var deviceTypes = map[string]vk.PhysicalDeviceType{
+	"discrete":   vk.PhysicalDeviceTypeDiscreteGpu,
+	"integrated": vk.PhysicalDeviceTypeIntegratedGpu,
+	"virtual":    vk.PhysicalDeviceTypeVirtualGpu,
+	"cpu":        vk.PhysicalDeviceTypeCpu,
+}
+
+// subcommands are the first arguments RunCLI accepts before the flags.
+// "info" is the default.
+var subcommands = map[string]bool{
+	"list":  true,
+	"info":  true,
+	"check": true,
+}
+
+// RunCLI runs the vulkandevice command with args, the command line without
+// the program name, and returns the exit code.
+func RunCLI(args []string) int {
+	command := "info"
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+	fs := flag.NewFlagSet("vulkandevice", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(fs.Output(), "Usage: vulkandevice [info] [flags]\n")
+		fmt.Fprintf(fs.Output(), "       vulkandevice list [flags]\n")
+		fmt.Fprintf(fs.Output(), "       vulkandevice check -require-... [flags]\n")
+		fmt.Fprintf(fs.Output(), "       vulkandevice -diff old.json [new.json]\n\n")
+		fmt.Fprintf(fs.Output(), "Reports the Vulkan instance and the GPUs it enumerates. By default every GPU\n")
+		fmt.Fprintf(fs.Output(), "is listed; -gpu, -device-type, -device-name and -vendor-id report one.\n")
+		fmt.Fprintf(fs.Output(), "The device is created on the GPU chosen by -gpu, else -device-name, else\n")
+		fmt.Fprintf(fs.Output(), "-vendor-id, else -device-type, else the best GPU ranked by -prefer, else\n")
+		fmt.Fprintf(fs.Output(), "GPU 0. -prefer can't be combined with -gpu, -device-name or -device-type.\n")
+		fmt.Fprintf(fs.Output(), "list prints one line per GPU, and check tests the selected GPU against the\n")
+		fmt.Fprintf(fs.Output(), "-require-* flags.\n\n")
+		fmt.Fprintf(fs.Output(), "Exit status: 0 success, 1 error, 2 usage error, 3 no GPUs found, 4 a\n")
+		fmt.Fprintf(fs.Output(), "requirement failed, 5 the Vulkan loader could not be initialized. -diff\n")
+		fmt.Fprintf(fs.Output(), "exits with 1 if the reports differ and 2 on errors.\n\n")
+		fs.PrintDefaults()
+	}
+	if !subcommands[command] {
+		fmt.Fprintf(fs.Output(), "vulkandevice: unknown command %s\n", command)
+		fs.Usage()
+		return exitUsage
+	}
+	output := fs.String("output", "table", "output format: table, json, yaml, csv or markdown")
+	jsonOutput := fs.Bool("json", false, "shorthand for -output json")
+	yamlOutput := fs.Bool("yaml", false, "shorthand for -output yaml")
+	csvOutput := fs.Bool("csv", false, "shorthand for -output csv")
+	markdownOutput := fs.Bool("markdown", false, "shorthand for -output markdown, for pasting into bug reports")
+	gpuIndex := fs.Int("gpu", 0, "index of the GPU to create the device on and report; if unset, GPU 0 or the one -prefer picks")
+	deviceType := fs.String("device-type", "any", "report the first GPU of this type: discrete, integrated, virtual, cpu or any")
+	prefer := fs.String("prefer", "", "pick the best GPU, preferring this type: discrete, integrated or any; with -vendor-id, picks among that vendor's GPUs")
+	deviceName := fs.String("device-name", "", "report the GPU whose name contains this string, ignoring case")
+	vendorID := fs.String("vendor-id", "", "report a GPU with this PCI vendor ID, such as 0x10de")
+	list := fs.Bool("list", false, "print one line per GPU, such as \"0: NAME (Discrete GPU, API 1.3.260)\", and exit")
+	listAll := fs.Bool("list-all", false, "report every GPU even if one was selected")
+	queues := fs.Bool("queues", false, "list the queue families of each GPU")
+	memory := fs.Bool("memory", false, "list the memory heaps and types of each GPU")
+	extensions := fs.Bool("extensions", false, "list the device extensions of each GPU")
+	limits := fs.Bool("limits", false, "list every device limit of each GPU")
+	features := fs.Bool("features", false, "list the Vulkan 1.0 features supported by each GPU")
+	formats := fs.Bool("formats", false, "list the features of each format supported by each GPU")
+	formatsAll := fs.Bool("formats-all", false, "like -formats, but include formats with no supported features")
+	subgroups := fs.Bool("subgroups", false, "list the subgroup size, stages and operations of each GPU")
+	sparse := fs.Bool("sparse", false, "list the sparse binding and residency support of each GPU")
+	displays := fs.Bool("displays", false, "list the displays attached to each GPU, their modes and the display planes, through VK_KHR_display")
+	external := fs.Bool("external", false, "list which external memory, semaphore and fence handle types each GPU can export and import")
+	descriptorIndexing := fs.Bool("descriptor-indexing", false, "list the descriptor indexing (bindless) limits and features of each GPU")
+	meshShaders := fs.Bool("mesh-shaders", false, "list the task and mesh shader limits and features of each GPU supporting VK_EXT_mesh_shader or VK_NV_mesh_shader")
+	rayTracing := fs.Bool("raytracing", false, "report whether each GPU can trace rays, the max recursion depth and the acceleration structure limits")
+	compression := fs.Bool("compression", false, "summarize the BC, ETC2 and ASTC texture compression support of each GPU")
+	formatName := fs.String("format", "", "report the features and image limits of the named format, such as R8G8B8A8_UNORM")
+	noDevice := fs.Bool("no-device", false, "only query the instance and GPUs, without creating a logical device")
+	validate := fs.Bool("validate", false, "enable VK_LAYER_KHRONOS_validation, if installed, and print its messages to stderr")
+	save := fs.String("save", "", "write the JSON report of every GPU to this file, for -diff, and exit")
+	diff := fs.String("diff", "", "print the differences between this saved report and the one given as argument, or the live system; exits 1 if any")
+	watchInterval := fs.Duration("watch", 0, "redraw the memory budget of each GPU at this interval, such as 2s, until interrupted")
+	watchLog := fs.String("watch-log", "", "like -watch, but append timestamped CSV rows to this file instead of redrawing")
+	serveAddr := fs.String("serve", "", "serve the JSON report over HTTP on this address, such as :8080, until interrupted")
+	var requirements vulkandevice.Requirements
+	fs.Var((*stringList)(&requirements.Extensions), "require-extension", "check that the GPU supports this device extension; repeatable")
+	fs.Var((*stringList)(&requirements.APIVersions), "require-api", "check that the GPU supports at least this Vulkan version, such as 1.2; repeatable")
+	fs.Var((*stringList)(&requirements.Limits), "require-limit", "check a limit, such as maxImageDimension2D>=16384 or maxComputeWorkGroupSize[0]>=1024; repeatable")
+	fs.Var((*stringList)(&requirements.Features), "require-feature", "check that the GPU supports this feature, such as samplerAnisotropy; repeatable")
+	surface := fs.Bool("surface", false, "create a hidden window and report its surface capabilities (requires -tags glfw)")
+	printVersionAndExit := fs.Bool("version", false, "print the build version and the vulkan-go binding version, and exit")
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return exitOK
+		}
+		return exitUsage
+	}
+	if *printVersionAndExit {
+		printVersion(os.Stdout)
+		return exitOK
+	}
+	if *diff != "" {
+		return runDiff(*diff, fs.Args())
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(fs.Output(), "vulkandevice: unexpected argument %s\n", fs.Arg(0))
+		fs.Usage()
+		return exitUsage
+	}
+	hasRequirements := len(requirements.Extensions)+len(requirements.APIVersions)+len(requirements.Limits)+len(requirements.Features) > 0
+	switch {
+	case command == "list":
+		*list = true
+	case command == "check" && !hasRequirements:
+		fmt.Fprintln(fs.Output(), "vulkandevice: check needs at least one -require-* flag")
+		fs.Usage()
+		return exitUsage
+	}
+	gpuSelected, gpuIndexSet, preferSet := false, false, false
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "gpu":
+			gpuSelected, gpuIndexSet = true, true
+		case "device-name", "vendor-id":
+			gpuSelected = true
+		case "device-type":
+			gpuSelected = *deviceType != "any"
+		case "prefer":
+			preferSet = true
+		}
+	})
+	if preferSet && (gpuIndexSet || *deviceName != "" || *deviceType != "any") {
+		fmt.Fprintln(fs.Output(), "vulkandevice: -prefer can't be combined with -gpu, -device-name or -device-type")
+		fs.Usage()
+		return exitUsage
+	}
+	if *listAll {
+		gpuSelected = false
+	}
+	if *jsonOutput {
+		*output = "json"
+	}
+	if *yamlOutput {
+		*output = "yaml"
+	}
+	if *csvOutput {
+		*output = "csv"
+	}
+	if *markdownOutput {
+		*output = "markdown"
+	}
+	switch *output {
+	case "table", "json", "yaml", "csv", "markdown":
+	default:
+		return fail(exitUsage, fmt.Errorf("unknown output format %s, want table, json, yaml, csv or markdown", *output))
+	}
+
+	opts := []vulkandevice.Option{vulkandevice.WithLogger(vulkandevice.DefaultLogger())}
+	if debugBuild {
+		opts = append(opts, vulkandevice.WithValidationLayers(), vulkandevice.WithDebugLog(func(flags vk.DebugReportFlags, msg string) {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", vulkandevice.DebugReportFlagNames(flags), msg)
+		}))
+	}
+	if gpuIndexSet {
+		opts = append(opts, vulkandevice.WithPhysicalDeviceIndex(*gpuIndex))
+	}
+	if *watchLog != "" && *watchInterval == 0 {
+		*watchInterval = defaultWatchInterval
+	}
+	if *watchInterval < 0 {
+		return fail(exitUsage, fmt.Errorf("invalid -watch interval %s", *watchInterval))
+	}
+	if *displays {
+		opts = append(opts, vulkandevice.WithDisplays())
+	}
+	if *noDevice || *list || *watchInterval > 0 {
+		opts = append(opts, vulkandevice.WithoutLogicalDevice())
+	}
+	switch *prefer {
+	case "":
+	case "any":
+		opts = append(opts, vulkandevice.WithPickDevice(vulkandevice.DefaultDeviceScorer(nil)))
+	case "discrete", "integrated":
+		opts = append(opts, vulkandevice.WithPickDevice(vulkandevice.DefaultDeviceScorer(nil, deviceTypes[*prefer])))
+	default:
+		return fail(exitUsage, fmt.Errorf("unknown -prefer %s, want discrete, integrated or any", *prefer))
+	}
+	if *deviceType != "any" {
+		t, ok := deviceTypes[*deviceType]
+		if !ok {
+			return fail(exitUsage, fmt.Errorf("unknown device type %s, want discrete, integrated, virtual, cpu or any", *deviceType))
+		}
+		opts = append(opts, vulkandevice.WithDeviceSelector(vulkandevice.OnlyDeviceType(t)))
+	}
+	if *deviceName != "" {
+		opts = append(opts, vulkandevice.WithDeviceName(*deviceName))
+	}
+	if *vendorID != "" {
+		id, err := strconv.ParseUint(*vendorID, 0, 32)
+		if err != nil {
+			return fail(exitUsage, fmt.Errorf("invalid vendor ID %s: %s", *vendorID, err))
+		}
+		opts = append(opts, vulkandevice.WithVendorID(uint32(id)))
+	}
+	format := vk.FormatUndefined
+	if *formatName != "" {
+		var ok bool
+		if format, ok = vulkandevice.LookupFormat(*formatName); !ok {
+			err := fmt.Errorf("unknown format %s", *formatName)
+			if matches := vulkandevice.CloseFormatNames(*formatName, 5); len(matches) > 0 {
+				err = fmt.Errorf("%s, did you mean %s?", err, strings.Join(matches, ", "))
+			}
+			return fail(exitError, err)
+		}
+	}
+
+	if err := vk.SetDefaultGetInstanceProcAddr(); err != nil {
+		return fail(exitLoader, err)
+	}
+	if err := vk.Init(); err != nil {
+		return fail(exitLoader, err)
+	}
+	if *validate {
+		opts = append(opts, validationOptions()...)
+	}
+	if *serveAddr != "" {
+		return serve(*serveAddr, append(opts, vulkandevice.WithoutLogicalDevice()))
+	}
+	if *surface {
+		windowExtensions, err := initSurfaceWindow()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "vulkandevice: %s, skipping surface info\n", err)
+			*surface = false
+		} else {
+			// Runs after vkDevice.Destroy, which destroys the surface.
+			defer terminateSurfaceWindow()
+			opts = append(opts, vulkandevice.WithInstanceExtensions(windowExtensions...))
+		}
+	}
+	vkDevice, err := vulkandevice.NewVulkanDevice(appInfo, 0, opts...)
+	if errors.Is(err, vulkandevice.ErrNoPhysicalDevices) {
+		return fail(exitNoDevices, err)
+	} else if err != nil {
+		return fail(exitError, err)
+	}
+	defer vkDevice.Destroy()
+	if *list {
+		devices, err := vkDevice.ListDevices()
+		if err != nil {
+			return fail(exitError, err)
+		}
+		for _, d := range devices {
+			fmt.Printf("%d: %s (%s, API %s)\n", d.Index, d.Name, d.DeviceType, d.APIVersion)
+		}
+		return exitOK
+	}
+	if *save != "" {
+		if err := saveReport(*save, vkDevice); err != nil {
+			return fail(exitError, err)
+		}
+		return exitOK
+	}
+	if *watchInterval > 0 {
+		gpus := []int{vkDevice.GPUIndex()}
+		if !gpuSelected {
+			devices, err := vkDevice.ListDevices()
+			if err != nil {
+				return fail(exitError, err)
+			}
+			gpus = gpus[:0]
+			for _, d := range devices {
+				gpus = append(gpus, d.Index)
+			}
+		}
+		return watch(vkDevice, gpus, *watchInterval, *watchLog)
+	}
+	if hasRequirements {
+		return checkRequirements(vkDevice, requirements)
+	}
+	if *surface {
+		if err := createWindowSurface(vkDevice); err != nil {
+			fmt.Fprintf(os.Stderr, "vulkandevice: %s, skipping surface info\n", err)
+			*surface = false
+		}
+	}
+
+	var sections []func(w io.Writer, v *vulkandevice.VulkanDeviceInfo, gpuIndex int)
+	if *queues {
+		sections = append(sections, vulkandevice.PrintQueueFamilies)
+	}
+	if *memory {
+		sections = append(sections, vulkandevice.PrintMemoryInfo)
+	}
+	if *extensions {
+		sections = append(sections, vulkandevice.PrintExtensions)
+	}
+	if *limits {
+		sections = append(sections, vulkandevice.PrintAllDeviceLimits)
+	}
+	if *features {
+		sections = append(sections, vulkandevice.PrintDeviceFeatures, vulkandevice.PrintCoreFeatures)
+	}
+	if *subgroups {
+		sections = append(sections, vulkandevice.PrintSubgroupInfo)
+	}
+	if *sparse {
+		sections = append(sections, vulkandevice.PrintSparseResources)
+	}
+	if *displays {
+		sections = append(sections, vulkandevice.PrintDisplays)
+	}
+	if *external {
+		sections = append(sections, vulkandevice.PrintExternalInterop)
+	}
+	if *descriptorIndexing {
+		sections = append(sections, vulkandevice.PrintDescriptorIndexing)
+	}
+	if *meshShaders {
+		sections = append(sections, vulkandevice.PrintMeshShaderInfo)
+	}
+	if *rayTracing {
+		sections = append(sections, vulkandevice.PrintRayTracingInfo)
+	}
+	if *compression {
+		sections = append(sections, vulkandevice.PrintTextureCompression)
+	}
+	if *formats || *formatsAll {
+		sections = append(sections, func(w io.Writer, v *vulkandevice.VulkanDeviceInfo, gpuIndex int) {
+			vulkandevice.PrintFormatProperties(w, v, gpuIndex, *formatsAll)
+		})
+	}
+	if *formatName != "" {
+		sections = append(sections, func(w io.Writer, v *vulkandevice.VulkanDeviceInfo, gpuIndex int) {
+			vulkandevice.PrintFormat(w, v, gpuIndex, format)
+		})
+	}
+	if *surface {
+		sections = append(sections, vulkandevice.PrintSurfaceInfo)
+	}
+	gpu := vkDevice.GPUIndex()
+	switch {
+	case *output == "csv":
+		if err := vulkandevice.WriteDeviceInfoCSV(vkDevice, os.Stdout); err != nil {
+			return fail(exitError, err)
+		}
+	case gpuSelected && *output == "markdown":
+		if err := vulkandevice.PrintMarkdown(os.Stdout, vkDevice, gpu); err != nil {
+			return fail(exitError, err)
+		}
+	case *output == "markdown":
+		if err := vulkandevice.PrintMarkdown(os.Stdout, vkDevice); err != nil {
+			return fail(exitError, err)
+		}
+	case gpuSelected && *output == "json":
+		out, err := vulkandevice.DeviceInfoJSON(vkDevice, gpu)
+		if err != nil {
+			return fail(exitError, err)
+		}
+		fmt.Println(string(out))
+	case gpuSelected && *output == "yaml":
+		out, err := vulkandevice.DeviceInfoYAML(vkDevice, gpu)
+		if err != nil {
+			return fail(exitError, err)
+		}
+		fmt.Print(string(out))
+	case gpuSelected:
+		vulkandevice.PrintInfo(os.Stdout, vkDevice)
+		for _, section := range sections {
+			section(os.Stdout, vkDevice, gpu)
+		}
+	case *output == "json":
+		if err := vulkandevice.PrintJSON(os.Stdout, vkDevice); err != nil {
+			return fail(exitError, err)
+		}
+	case *output == "yaml":
+		if err := vulkandevice.PrintYAML(os.Stdout, vkDevice); err != nil {
+			return fail(exitError, err)
+		}
+	default:
+		vulkandevice.PrintAllDevices(os.Stdout, vkDevice, sections...)
+	}
+	return exitOK
+}
+
+// validationOptions enables the validation layer, or warns if it isn't
+// installed, and prints the debug_utils warnings and errors to stderr.
+func validationOptions() []vulkandevice.Option {
+	severity := vk.DebugUtilsMessageSeverityFlags(vk.DebugUtilsMessageSeverityWarningBit | vk.DebugUtilsMessageSeverityErrorBit)
+	msgType := vk.DebugUtilsMessageTypeFlags(vk.DebugUtilsMessageTypeGeneralBit |
+		vk.DebugUtilsMessageTypeValidationBit | vk.DebugUtilsMessageTypePerformanceBit)
+	opts := []vulkandevice.Option{vulkandevice.WithDebugMessenger(severity, msgType, func(severity, msgType, msg string) {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", severity, msg)
+	})}
+	layers, _ := vulkandevice.EnumerateLayers()
+	for _, layer := range layers {
+		if vk.ToString(layer.LayerName[:]) == validationLayerName {
+			return append(opts, vulkandevice.WithValidationLayers())
+		}
+	}
+	fmt.Fprintf(os.Stderr, "vulkandevice: warning: %s is not installed, continuing without it\n", validationLayerName)
+	return opts
+}
+
+// checkRequirements prints a PASS or FAIL line per requirement for the
+// selected GPU and returns exitRequirementFailed if any of them failed.
+func checkRequirements(v *vulkandevice.VulkanDeviceInfo, requirements vulkandevice.Requirements) int {
+	results, err := vulkandevice.CheckRequirements(v, v.GPUIndex(), requirements)
+	if err != nil {
+		return fail(exitError, err)
+	}
+	code := exitOK
+	for _, result := range results {
+		fmt.Println(result)
+		if !result.Passed {
+			code = exitRequirementFailed
+		}
+	}
+	return code
+}
+
+// stringList is a flag.Value collecting the values of a repeated flag.
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(s string) error {
+	*l = append(*l, s)
+	return nil
+}
+
+// fail reports err on stderr and returns code for RunCLI to exit with.
+func fail(code int, err error) int {
+	fmt.Fprintln(os.Stderr, "vulkandevice:", err)
+	return code
+}
@@ -0,0 +1,6 @@
+//go:build debug
+
+package main
+
+// debugBuild enables validation layers in main. Build with -tags debug.
+const debugBuild = true
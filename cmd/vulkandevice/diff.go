@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Buhrietoe/vulkandevice"
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// runDiff compares the report saved at oldPath with the one saved at the
+// only element of args, or with the live system if args is empty, and
+// prints one line per difference.
+func runDiff(oldPath string, args []string) int {
+	if len(args) > 1 {
+		return fail(exitDiffError, fmt.Errorf("-diff takes at most one more report, got %d", len(args)))
+	}
+	before, err := loadReport(oldPath)
+	if err != nil {
+		return fail(exitDiffError, err)
+	}
+	var after *vulkandevice.Report
+	if len(args) == 1 {
+		after, err = loadReport(args[0])
+	} else {
+		after, err = liveReport()
+	}
+	if err != nil {
+		return fail(exitDiffError, err)
+	}
+
+	diffs := vulkandevice.DiffReports(before, after)
+	for _, d := range diffs {
+		fmt.Println(d)
+	}
+	if len(diffs) > 0 {
+		return exitDiffFound
+	}
+	return exitOK
+}
+
+func loadReport(path string) (*vulkandevice.Report, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	report, err := vulkandevice.LoadReport(f)
+	if err != nil {
+		err = fmt.Errorf("%s: %s", path, err)
+		return nil, err
+	}
+	return report, nil
+}
+
+// liveReport gathers the report of the system without creating a logical
+// device.
+func liveReport() (*vulkandevice.Report, error) {
+	if err := vk.SetDefaultGetInstanceProcAddr(); err != nil {
+		return nil, err
+	}
+	if err := vk.Init(); err != nil {
+		return nil, err
+	}
+	vkDevice, err := vulkandevice.NewVulkanDevice(appInfo, 0,
+		vulkandevice.WithLogger(vulkandevice.DefaultLogger()), vulkandevice.WithoutLogicalDevice())
+	if err != nil {
+		return nil, err
+	}
+	defer vkDevice.Destroy()
+	return vulkandevice.GatherDeviceReport(vkDevice)
+}
+
+// saveReport writes the JSON report of v to path, for -diff.
+func saveReport(path string, v *vulkandevice.VulkanDeviceInfo) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := vulkandevice.PrintJSON(f, v); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
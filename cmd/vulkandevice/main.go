@@ -0,0 +1,19 @@
+// Command vulkandevice reports the Vulkan instance and GPUs of the system.
+package main
+
+import (
+	"os"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+var appInfo = &vk.ApplicationInfo{
+	SType:              vk.StructureTypeApplicationInfo,
+	ApplicationVersion: vk.MakeVersion(1, 0, 0),
+	PApplicationName:   "VulkanDevice\x00",
+	PEngineName:        "vulkango.com\x00",
+}
+
+func main() {
+	os.Exit(RunCLI(os.Args[1:]))
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Buhrietoe/vulkandevice"
+)
+
+// shutdownTimeout is how long -serve waits for requests in flight on
+// SIGINT or SIGTERM.
+const shutdownTimeout = 5 * time.Second
+
+// deviceServer serves the report of the system over HTTP for -serve. The
+// report is gathered once and kept until a request asks for ?refresh=1.
+type deviceServer struct {
+	opts []vulkandevice.Option
+
+	mu     sync.Mutex
+	device *vulkandevice.VulkanDeviceInfo
+	report *vulkandevice.Report
+}
+
+// newDeviceServer creates the instance with opts and gathers the report.
+func newDeviceServer(opts []vulkandevice.Option) (*deviceServer, error) {
+	s := &deviceServer{opts: opts}
+	if err := s.refresh(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// refresh re-creates the instance, so GPUs plugged in or removed since the
+// last one are seen, and replaces the report.
+func (s *deviceServer) refresh() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	v, err := vulkandevice.NewVulkanDevice(appInfo, 0, s.opts...)
+	if err != nil {
+		return err
+	}
+	report, err := vulkandevice.GatherDeviceReport(v)
+	if err != nil {
+		v.Destroy()
+		return err
+	}
+	if s.device != nil {
+		s.device.Destroy()
+	}
+	s.device, s.report = v, report
+	return nil
+}
+
+// cachedReport returns the report, refreshing it first if r asks for it.
+func (s *deviceServer) cachedReport(r *http.Request) (*vulkandevice.Report, error) {
+	if r.URL.Query().Get("refresh") == "1" {
+		if err := s.refresh(); err != nil {
+			return nil, err
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.report, nil
+}
+
+// destroy releases the instance.
+func (s *deviceServer) destroy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.device != nil {
+		s.device.Destroy()
+		s.device = nil
+	}
+}
+
+func (s *deviceServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	mux.HandleFunc("/devices", s.serveDevices)
+	mux.HandleFunc("/devices/", s.serveDevice)
+	mux.HandleFunc("/metrics", s.serveMetrics)
+	return mux
+}
+
+// serveDevices handles GET /devices with the full report.
+func (s *deviceServer) serveDevices(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	report, err := s.cachedReport(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, report)
+}
+
+// serveDevice handles GET /devices/{index} with the report of one GPU.
+func (s *deviceServer) serveDevice(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	index, err := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/devices/"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	report, err := s.cachedReport(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if index < 0 || index >= len(report.Devices) {
+		http.NotFound(w, r)
+		return
+	}
+	writeJSON(w, report.Devices[index])
+}
+
+// serveMetrics handles GET /metrics with the memory heap gauges. The
+// budget and usage are queried on every scrape.
+func (s *deviceServer) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	vulkandevice.WriteMemoryMetrics(w, s.device)
+}
+
+// writeJSON encodes value before writing it, so an encoding error can
+// still be sent as a 500.
+func writeJSON(w http.ResponseWriter, value interface{}) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(value); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		fmt.Fprintln(os.Stderr, "vulkandevice:", err)
+	}
+}
+
+// serve runs the -serve HTTP server on addr until SIGINT or SIGTERM, then
+// shuts it down and destroys the instance.
+func serve(addr string, opts []vulkandevice.Option) int {
+	s, err := newDeviceServer(opts)
+	if errors.Is(err, vulkandevice.ErrNoPhysicalDevices) {
+		return fail(exitNoDevices, err)
+	} else if err != nil {
+		return fail(exitError, err)
+	}
+	defer s.destroy()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	server := &http.Server{Addr: addr, Handler: s.handler()}
+	errc := make(chan error, 1)
+	go func() {
+		errc <- server.ListenAndServe()
+	}()
+	fmt.Fprintf(os.Stderr, "vulkandevice: serving on %s\n", addr)
+
+	select {
+	case err := <-errc:
+		return fail(exitError, err)
+	case <-ctx.Done():
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fail(exitError, err)
+	}
+	return exitOK
+}
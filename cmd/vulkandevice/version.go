@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"runtime/debug"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// version is the build version printed by -version. Release builds set it
+// with -ldflags "-X main.version=v1.2.3"; otherwise the module version from
+// the build info is used.
+var version = ""
+
+// bindingModule is the module path of the Vulkan bindings.
+const bindingModule = "github.com/vulkan-go/vulkan"
+
+// printVersion prints the build version, the version of the Vulkan bindings
+// and the Vulkan headers they were generated from, and the Go version.
+func printVersion(w io.Writer) {
+	buildVersion, bindingVersion := version, "unknown"
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if buildVersion == "" {
+			buildVersion = info.Main.Version
+		}
+		for _, dep := range info.Deps {
+			if dep.Path == bindingModule {
+				bindingVersion = dep.Version
+			}
+		}
+	}
+	if buildVersion == "" {
+		buildVersion = "(devel)"
+	}
+	fmt.Fprintf(w, "vulkandevice %s\n", buildVersion)
+	fmt.Fprintf(w, "%s %s (Vulkan header version %d)\n", bindingModule, bindingVersion, vk.HeaderVersion)
+	fmt.Fprintf(w, "%s\n", runtime.Version())
+}
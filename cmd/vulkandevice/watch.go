@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/Buhrietoe/vulkandevice"
+)
+
+// defaultWatchInterval is the -watch-log interval when -watch isn't given.
+const defaultWatchInterval = 2 * time.Second
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\x1b[H\x1b[2J"
+
+// watchLogHeader is the header row of -watch-log files.
+var watchLogHeader = []string{"timestamp", "gpu", "name", "heap", "size", "budget", "usage"}
+
+// watch redraws the memory budget of gpus every interval, or appends it to
+// the CSV file logPath if it isn't empty, until SIGINT or SIGTERM. v is kept
+// for the whole run.
+func watch(v *vulkandevice.VulkanDeviceInfo, gpus []int, interval time.Duration, logPath string) int {
+	report, err := vulkandevice.GatherDeviceReport(v)
+	if err != nil {
+		return fail(exitError, err)
+	}
+	tick := func() error {
+		redrawMemoryBudget(os.Stdout, v, gpus, interval)
+		return nil
+	}
+	if logPath != "" {
+		f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fail(exitError, err)
+		}
+		defer f.Close()
+		w := csv.NewWriter(f)
+		if info, err := f.Stat(); err == nil && info.Size() == 0 {
+			w.Write(watchLogHeader)
+		}
+		tick = func() error {
+			logMemoryBudget(w, v, report, gpus)
+			w.Flush()
+			return w.Error()
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	resized := make(chan os.Signal, 1)
+	if len(resizeSignals) > 0 {
+		signal.Notify(resized, resizeSignals...)
+		defer signal.Stop(resized)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := tick(); err != nil {
+			return fail(exitError, err)
+		}
+		select {
+		case <-ctx.Done():
+			return exitOK
+		case <-ticker.C:
+		case <-resized:
+		}
+	}
+}
+
+// redrawMemoryBudget clears the terminal and prints the budget table of
+// each GPU, written at once so the screen doesn't flicker.
+func redrawMemoryBudget(w io.Writer, v *vulkandevice.VulkanDeviceInfo, gpus []int, interval time.Duration) {
+	var buf bytes.Buffer
+	buf.WriteString(clearScreen)
+	fmt.Fprintf(&buf, "Every %s: %s (Ctrl-C to exit)\n", interval, time.Now().Format("15:04:05"))
+	for _, gpu := range gpus {
+		vulkandevice.PrintMemoryBudget(&buf, v, gpu)
+	}
+	w.Write(buf.Bytes())
+}
+
+// logMemoryBudget writes one row per heap of each GPU. The budget and usage
+// columns are empty for GPUs without VK_EXT_memory_budget.
+func logMemoryBudget(w *csv.Writer, v *vulkandevice.VulkanDeviceInfo, report *vulkandevice.Report, gpus []int) {
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	for _, gpu := range gpus {
+		device := report.Devices[gpu]
+		budget, err := vulkandevice.GetMemoryBudget(v, gpu)
+		for i, heap := range device.MemoryHeaps {
+			row := []string{timestamp, strconv.Itoa(gpu), device.Name, strconv.Itoa(i),
+				strconv.FormatUint(heap.Size, 10), "", ""}
+			if err == nil {
+				row[5] = strconv.FormatUint(budget[i].Budget, 10)
+				row[6] = strconv.FormatUint(budget[i].Usage, 10)
+			}
+			w.Write(row)
+		}
+	}
+}
@@ -0,0 +1,12 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"syscall"
+)
+
+// resizeSignals are delivered when the terminal is resized, so -watch
+// redraws right away.
+var resizeSignals = []os.Signal{syscall.SIGWINCH}
@@ -0,0 +1,9 @@
+//go:build windows
+
+package main
+
+import "os"
+
+// resizeSignals is empty on Windows, which has no resize signal; -watch
+// picks up the new size on the next tick.
+var resizeSignals []os.Signal
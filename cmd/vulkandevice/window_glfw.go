@@ -0,0 +1,68 @@
+//go:build glfw
+
+package main
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+
+	"github.com/Buhrietoe/vulkandevice"
+	"github.com/go-gl/glfw/v3.3/glfw"
+	vk "github.com/vulkan-go/vulkan"
+)
+
+func init() {
+	// GLFW must be called from the main thread.
+	runtime.LockOSThread()
+}
+
+// surfaceWindow is the hidden window created by initSurfaceWindow.
+var surfaceWindow *glfw.Window
+
+// initSurfaceWindow creates a hidden GLFW window to report surface
+// capabilities for, and returns the NUL-terminated instance extensions
+// needed to create a surface for it.
+func initSurfaceWindow() ([]string, error) {
+	if err := glfw.Init(); err != nil {
+		err = fmt.Errorf("no display available: %s", err)
+		return nil, err
+	}
+	glfw.WindowHint(glfw.ClientAPI, glfw.NoAPI)
+	glfw.WindowHint(glfw.Visible, glfw.False)
+	window, err := glfw.CreateWindow(640, 480, "vulkandevice", nil, nil)
+	if err != nil {
+		glfw.Terminate()
+		err = fmt.Errorf("no display available: %s", err)
+		return nil, err
+	}
+	surfaceWindow = window
+	var extensions []string
+	for _, name := range window.GetRequiredInstanceExtensions() {
+		extensions = append(extensions, name+"\x00")
+	}
+	return extensions, nil
+}
+
+// createWindowSurface creates a surface for the window from
+// initSurfaceWindow and sets it as v's surface.
+func createWindowSurface(v *vulkandevice.VulkanDeviceInfo) error {
+	instance, allocator := v.Instance()
+	surface, err := surfaceWindow.CreateWindowSurface(instance, unsafe.Pointer(allocator))
+	if err != nil {
+		err = fmt.Errorf("%w: %s", vulkandevice.ErrSurfaceCreationFailed, err)
+		return err
+	}
+	v.SetSurface(vk.SurfaceFromPointer(surface))
+	return nil
+}
+
+// terminateSurfaceWindow destroys the window from initSurfaceWindow. Its
+// surface must already be destroyed.
+func terminateSurfaceWindow() {
+	if surfaceWindow != nil {
+		surfaceWindow.Destroy()
+		surfaceWindow = nil
+	}
+	glfw.Terminate()
+}
@@ -0,0 +1,23 @@
+//go:build !glfw
+
+package main
+
+import (
+	"errors"
+
+	"github.com/Buhrietoe/vulkandevice"
+)
+
+// errNoGLFW is returned when surface reporting is requested from a build
+// without GLFW.
+var errNoGLFW = errors.New("built without GLFW support, rebuild with -tags glfw")
+
+func initSurfaceWindow() ([]string, error) {
+	return nil, errNoGLFW
+}
+
+func createWindowSurface(v *vulkandevice.VulkanDeviceInfo) error {
+	return errNoGLFW
+}
+
+func terminateSurfaceWindow() {}
@@ -0,0 +1,133 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"sync"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// CreateCommandPool creates a command pool for queue family
+// queueFamilyIndex. Destroy it with vk.DestroyCommandPool, or use a
+// CommandPoolManager.
+func CreateCommandPool(v *VulkanDeviceInfo, queueFamilyIndex uint32, flags vk.CommandPoolCreateFlags) (vk.CommandPool, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return vk.NullCommandPool, ErrNoDevice
+	}
+	createInfo := &vk.CommandPoolCreateInfo{
+		SType:            vk.StructureTypeCommandPoolCreateInfo,
+		Flags:            flags,
+		QueueFamilyIndex: queueFamilyIndex,
+	}
+	var pool vk.CommandPool
+	if err := vk.Error(vk.CreateCommandPool(v.device, createInfo, v.allocator, &pool)); err != nil {
+		err = fmt.Errorf("vkCreateCommandPool failed with %s", err)
+		return vk.NullCommandPool, err
+	}
+	return pool, nil
+}
+
+// AllocateCommandBuffers allocates count command buffers of level from
+// pool.
+func (v *VulkanDeviceInfo) AllocateCommandBuffers(pool vk.CommandPool, level vk.CommandBufferLevel, count uint32) ([]vk.CommandBuffer, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return nil, ErrNoDevice
+	}
+	allocateInfo := &vk.CommandBufferAllocateInfo{
+		SType:              vk.StructureTypeCommandBufferAllocateInfo,
+		CommandPool:        pool,
+		Level:              level,
+		CommandBufferCount: count,
+	}
+	buffers := make([]vk.CommandBuffer, count)
+	if err := vk.Error(vk.AllocateCommandBuffers(v.device, allocateInfo, buffers)); err != nil {
+		err = fmt.Errorf("vkAllocateCommandBuffers failed with %s", err)
+		return nil, err
+	}
+	return buffers, nil
+}
+
+// ResetCommandPool returns every command buffer allocated from pool to the
+// initial state.
+func (v *VulkanDeviceInfo) ResetCommandPool(pool vk.CommandPool) error {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return ErrNoDevice
+	}
+	if err := vk.Error(vk.ResetCommandPool(v.device, pool, 0)); err != nil {
+		err = fmt.Errorf("vkResetCommandPool failed with %s", err)
+		return err
+	}
+	return nil
+}
+
+// FreeCommandBuffers returns bufs to pool, which they were allocated from.
+func (v *VulkanDeviceInfo) FreeCommandBuffers(pool vk.CommandPool, bufs []vk.CommandBuffer) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil || len(bufs) == 0 {
+		return
+	}
+	vk.FreeCommandBuffers(v.device, pool, uint32(len(bufs)), bufs)
+}
+
+// CommandPoolManager creates one command pool per queue family on demand
+// and destroys them all in Destroy. It is safe for concurrent use, but each
+// pool must only be used by one goroutine at a time, as Vulkan requires.
+type CommandPoolManager struct {
+	v     *VulkanDeviceInfo
+	flags vk.CommandPoolCreateFlags
+
+	mu    sync.Mutex
+	pools map[uint32]vk.CommandPool
+}
+
+// NewCommandPoolManager returns a CommandPoolManager creating pools on v's
+// device with flags. It must be destroyed before v.
+func NewCommandPoolManager(v *VulkanDeviceInfo, flags vk.CommandPoolCreateFlags) *CommandPoolManager {
+	return &CommandPoolManager{
+		v:     v,
+		flags: flags,
+		pools: make(map[uint32]vk.CommandPool),
+	}
+}
+
+// Pool returns the command pool of queue family queueFamilyIndex, creating
+// it on first use.
+func (m *CommandPoolManager) Pool(queueFamilyIndex uint32) (vk.CommandPool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if pool, ok := m.pools[queueFamilyIndex]; ok {
+		return pool, nil
+	}
+	pool, err := CreateCommandPool(m.v, queueFamilyIndex, m.flags)
+	if err != nil {
+		return vk.NullCommandPool, err
+	}
+	m.pools[queueFamilyIndex] = pool
+	return pool, nil
+}
+
+// Destroy destroys every pool created by m, which frees their command
+// buffers. Calling it again is a no-op. The device must not be using the
+// command buffers.
+func (m *CommandPoolManager) Destroy() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.v.mu.RLock()
+	defer m.v.mu.RUnlock()
+	for family, pool := range m.pools {
+		if m.v.device != nil {
+			vk.DestroyCommandPool(m.v.device, pool, m.v.allocator)
+		}
+		delete(m.pools, family)
+	}
+}
@@ -0,0 +1,103 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// formatAstc4x4SfloatBlock is VK_FORMAT_ASTC_4x4_SFLOAT_BLOCK, which is
+// newer than the vulkan-go bindings.
+const formatAstc4x4SfloatBlock vk.Format = 1000066000
+
+// compressionFamily is a block-compressed texture format family, detected
+// from its feature bit and a representative format.
+type compressionFamily struct {
+	name    string
+	feature string
+	format  vk.Format
+}
+
+var compressionFamilies = []compressionFamily{
+	{"BC", "textureCompressionBC", vk.FormatBc7UnormBlock},
+	{"ETC2", "textureCompressionETC2", vk.FormatEtc2R8g8b8a8UnormBlock},
+	{"ASTC LDR", "textureCompressionASTC_LDR", vk.FormatAstc4x4UnormBlock},
+	{"ASTC HDR", "textureCompressionASTC_HDR", formatAstc4x4SfloatBlock},
+}
+
+// TextureCompression reports which block-compressed format families a GPU
+// can sample from.
+type TextureCompression struct {
+	BC      bool `json:"bc"`
+	ETC2    bool `json:"etc2"`
+	ASTCLDR bool `json:"astc_ldr"`
+	ASTCHDR bool `json:"astc_hdr"`
+}
+
+// compressionSupport holds the evidence for one family: the feature bit and
+// whether the representative format supports SAMPLED_IMAGE with optimal
+// tiling.
+type compressionSupport struct {
+	family  compressionFamily
+	feature bool
+	sampled bool
+}
+
+func (s compressionSupport) supported() bool {
+	return s.feature || s.sampled
+}
+
+func getCompressionSupport(v *VulkanDeviceInfo, gpuIndex int) []compressionSupport {
+	gpu := v.gpuDevices[gpuIndex]
+	features := GetDeviceFeatures(gpu)
+	enabled := map[string]bool{
+		"textureCompressionBC":       features.TextureCompressionBC.B(),
+		"textureCompressionETC2":     features.TextureCompressionETC2.B(),
+		"textureCompressionASTC_LDR": features.TextureCompressionASTC_LDR.B(),
+	}
+	if core, err := GetCoreFeatures(v, gpuIndex); err == nil && core.Vulkan13 != nil {
+		enabled["textureCompressionASTC_HDR"] = core.Vulkan13["textureCompressionASTC_HDR"]
+	}
+
+	support := make([]compressionSupport, len(compressionFamilies))
+	for i, family := range compressionFamilies {
+		properties := GetFormatProperties(gpu, family.format)
+		support[i] = compressionSupport{
+			family:  family,
+			feature: enabled[family.feature],
+			sampled: properties.OptimalTilingFeatures&vk.FormatFeatureFlags(vk.FormatFeatureSampledImageBit) != 0,
+		}
+	}
+	return support
+}
+
+// GetTextureCompression reports which block-compressed format families the
+// GPU at gpuIndex supports, either through the family's feature bit or
+// because a representative format can be sampled.
+func GetTextureCompression(v *VulkanDeviceInfo, gpuIndex int) TextureCompression {
+	support := getCompressionSupport(v, gpuIndex)
+	return TextureCompression{
+		BC:      support[0].supported(),
+		ETC2:    support[1].supported(),
+		ASTCLDR: support[2].supported(),
+		ASTCHDR: support[3].supported(),
+	}
+}
+
+// PrintTextureCompression prints one row per block-compressed format family
+// of the GPU at gpuIndex.
+func PrintTextureCompression(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Texture Compression", gpuIndex))
+	table.AddHeaders("Family", "Supported", "Feature", "Sampled Format")
+	for _, s := range getCompressionSupport(v, gpuIndex) {
+		table.AddRow(s.family.name, checkMark(s.supported()),
+			fmt.Sprintf("%s %s", checkMark(s.feature), s.family.feature),
+			fmt.Sprintf("%s %s", checkMark(s.sampled), FormatName(s.family.format)))
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
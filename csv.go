@@ -0,0 +1,131 @@
+package vulkandevice
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// csvHeader lists the CSV columns, named after the matching JSON fields.
+// New columns are only ever appended, so files written by different
+// versions can be concatenated.
+//
+// The columns are the scalar fields of DeviceReport, with versions split
+// into decoded and raw columns, a handful of limits and the PCI address.
+// The nested fields (features, queue families, memory heaps and types,
+// extensions, subgroup and texture compression support, and the other
+// limits) are left out because they don't fit one row per device; use the
+// JSON report for them. total_vram_mib, the size of the device-local heaps,
+// is the only value derived from them.
+var csvHeader = []string{
+	"index",
+	"name",
+	"vendor_id",
+	"vendor_name",
+	"device_id",
+	"device_type",
+	"api_version",
+	"api_version_raw",
+	"driver_version",
+	"driver_version_raw",
+	"pipeline_cache_uuid",
+	"total_vram_mib",
+	"max_image_dimension_2d",
+	"max_memory_allocation_count",
+	"max_bound_descriptor_sets",
+	"max_push_constants_size",
+	"max_compute_shared_memory_size",
+	"max_compute_work_group_invocations",
+	"device_uuid",
+	"driver_uuid",
+	"device_luid",
+	"device_node_mask",
+	"pci_address",
+	"driver_id",
+	"driver_name",
+	"driver_info",
+	"conformance_version",
+	"portability_subset",
+}
+
+// WriteDeviceInfoCSV writes a header row and one row per physical device to
+// w, for fleet inventories. Fields containing commas or quotes are quoted.
+func WriteDeviceInfoCSV(v *VulkanDeviceInfo, w io.Writer) error {
+	reports := make([]DeviceReport, len(v.gpuDevices))
+	for i := range v.gpuDevices {
+		reports[i] = newDeviceReport(v, i)
+	}
+	return writeDeviceReportsCSV(w, reports)
+}
+
+// writeDeviceReportsCSV is WriteDeviceInfoCSV for reports already gathered.
+func writeDeviceReportsCSV(w io.Writer, reports []DeviceReport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		err = fmt.Errorf("WriteDeviceInfoCSV: %s", err)
+		return err
+	}
+	for _, info := range reports {
+		if err := cw.Write(deviceReportCSVRow(info)); err != nil {
+			err = fmt.Errorf("WriteDeviceInfoCSV: %s", err)
+			return err
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		err = fmt.Errorf("WriteDeviceInfoCSV: %s", err)
+		return err
+	}
+	return nil
+}
+
+// deviceReportCSVRow returns the csvHeader columns of info.
+func deviceReportCSVRow(info DeviceReport) []string {
+	var vram uint64
+	for _, heap := range info.MemoryHeaps {
+		for _, flag := range heap.Flags {
+			if flag == "DEVICE_LOCAL" {
+				vram += heap.Size
+			}
+		}
+	}
+	var pciAddress string
+	if info.PCIBusInfo != nil {
+		pciAddress = info.PCIBusInfo.Address
+	}
+	return []string{
+		strconv.Itoa(info.Index),
+		info.Name,
+		formatUint32(info.VendorID),
+		info.VendorName,
+		formatUint32(info.DeviceID),
+		info.DeviceType,
+		info.APIVersion.Version,
+		formatUint32(info.APIVersion.Raw),
+		info.DriverVersion.Version,
+		formatUint32(info.DriverVersion.Raw),
+		info.PipelineCacheUUID,
+		strconv.FormatUint(vram>>20, 10),
+		formatUint32(info.Limits.MaxImageDimension2D),
+		formatUint32(info.Limits.MaxMemoryAllocationCount),
+		formatUint32(info.Limits.MaxBoundDescriptorSets),
+		formatUint32(info.Limits.MaxPushConstantsSize),
+		formatUint32(info.Limits.MaxComputeSharedMemorySize),
+		formatUint32(info.Limits.MaxComputeWorkGroupInvocations),
+		info.DeviceUUID,
+		info.DriverUUID,
+		info.DeviceLUID,
+		formatUint32(info.DeviceNodeMask),
+		pciAddress,
+		info.DriverID,
+		info.DriverName,
+		info.DriverInfo,
+		info.ConformanceVersion,
+		strconv.FormatBool(info.PortabilitySubset),
+	}
+}
+
+func formatUint32(n uint32) string {
+	return strconv.FormatUint(uint64(n), 10)
+}
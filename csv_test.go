@@ -0,0 +1,54 @@
+package vulkandevice
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+)
+
+func TestWriteDeviceReportsCSVColumnCount(t *testing.T) {
+	reports := []DeviceReport{
+		{
+			Index:      0,
+			Name:       "GPU, with \"quotes\"",
+			VendorID:   0x10de,
+			VendorName: "NVIDIA",
+			DeviceType: "Discrete GPU",
+			APIVersion: newVersion(1<<22 | 3<<12 | 260),
+			MemoryHeaps: []MemoryHeap{
+				{Index: 0, Size: 8 << 30, Flags: []string{"DEVICE_LOCAL"}},
+				{Index: 1, Size: 16 << 30, Flags: []string{}},
+			},
+			PCIBusInfo: &PCIBusInfo{Address: "0000:65:00.0"},
+			DriverName: "NVIDIA",
+			DriverInfo: "535.104.05",
+		},
+		{Index: 1, Name: "llvmpipe", DeviceType: "CPU"},
+	}
+	var buf bytes.Buffer
+	if err := writeDeviceReportsCSV(&buf, reports); err != nil {
+		t.Fatal(err)
+	}
+
+	r := csv.NewReader(&buf)
+	// Fail on rows of differing lengths instead of checking them below.
+	r.FieldsPerRecord = 0
+	records, err := r.ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != len(reports)+1 {
+		t.Fatalf("got %d rows, want %d", len(records), len(reports)+1)
+	}
+	for i, record := range records {
+		if len(record) != len(csvHeader) {
+			t.Errorf("row %d has %d columns, want %d", i, len(record), len(csvHeader))
+		}
+	}
+	if got := records[1][1]; got != reports[0].Name {
+		t.Errorf("name = %q, want %q", got, reports[0].Name)
+	}
+	if got := records[1][11]; got != "8192" {
+		t.Errorf("total_vram_mib = %s, want 8192", got)
+	}
+}
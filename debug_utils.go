@@ -0,0 +1,214 @@
+package vulkandevice
+
+/*
+#include <stdint.h>
+#include <stddef.h>
+#include <stdlib.h>
+
+// lookup_instance_proc is defined in proc.go.
+extern void *lookup_instance_proc(void *instance, const char *name);
+
+// debug_utils_callback_data has the leading fields of
+// VkDebugUtilsMessengerCallbackDataEXT.
+typedef struct {
+	int32_t sType;
+	const void *pNext;
+	uint32_t flags;
+	const char *pMessageIdName;
+	int32_t messageIdNumber;
+	const char *pMessage;
+} debug_utils_callback_data;
+
+typedef uint32_t (*debug_utils_callback_t)(uint32_t severity, uint32_t types,
+	const debug_utils_callback_data *data, void *userData);
+
+// debug_utils_messenger_create_info has the layout of
+// VkDebugUtilsMessengerCreateInfoEXT.
+typedef struct {
+	int32_t sType;
+	const void *pNext;
+	uint32_t flags;
+	uint32_t messageSeverity;
+	uint32_t messageType;
+	debug_utils_callback_t pfnUserCallback;
+	void *pUserData;
+} debug_utils_messenger_create_info;
+
+typedef int32_t (*create_debug_utils_messenger_t)(void *instance,
+	const debug_utils_messenger_create_info *createInfo, const void *allocator, uint64_t *messenger);
+typedef void (*destroy_debug_utils_messenger_t)(void *instance, uint64_t messenger, const void *allocator);
+
+extern void goDebugUtilsMessage(uint32_t severity, uint32_t types, char *idName, char *message, uintptr_t id);
+
+static uint32_t debug_utils_callback(uint32_t severity, uint32_t types,
+	const debug_utils_callback_data *data, void *userData) {
+	goDebugUtilsMessage(severity, types, (char *)data->pMessageIdName, (char *)data->pMessage,
+		(uintptr_t)userData);
+	return 0; // VK_FALSE, don't abort the call.
+}
+
+static void fill_debug_utils_messenger_create_info(debug_utils_messenger_create_info *createInfo,
+	uint32_t severity, uint32_t types, uintptr_t id) {
+	createInfo->sType = 1000128004; // VK_STRUCTURE_TYPE_DEBUG_UTILS_MESSENGER_CREATE_INFO_EXT
+	createInfo->pNext = NULL;
+	createInfo->flags = 0;
+	createInfo->messageSeverity = severity;
+	createInfo->messageType = types;
+	createInfo->pfnUserCallback = debug_utils_callback;
+	createInfo->pUserData = (void *)id;
+}
+
+// new_debug_utils_messenger_create_info allocates a create info for the
+// pNext chain of VkInstanceCreateInfo. Free it with free.
+static debug_utils_messenger_create_info *new_debug_utils_messenger_create_info(uint32_t severity,
+	uint32_t types, uintptr_t id) {
+	debug_utils_messenger_create_info *createInfo = malloc(sizeof(debug_utils_messenger_create_info));
+	fill_debug_utils_messenger_create_info(createInfo, severity, types, id);
+	return createInfo;
+}
+
+static int32_t create_debug_utils_messenger(void *instance, uint32_t severity, uint32_t types,
+	uintptr_t id, const void *allocator, uint64_t *messenger, int *found) {
+	create_debug_utils_messenger_t fn = (create_debug_utils_messenger_t)lookup_instance_proc(instance, "vkCreateDebugUtilsMessengerEXT");
+	*found = fn != NULL;
+	if (fn == NULL) {
+		return 0;
+	}
+	debug_utils_messenger_create_info createInfo;
+	fill_debug_utils_messenger_create_info(&createInfo, severity, types, id);
+	return fn(instance, &createInfo, allocator, messenger);
+}
+
+static void destroy_debug_utils_messenger(void *instance, uint64_t messenger, const void *allocator) {
+	destroy_debug_utils_messenger_t fn = (destroy_debug_utils_messenger_t)lookup_instance_proc(instance, "vkDestroyDebugUtilsMessengerEXT");
+	if (fn != NULL) {
+		fn(instance, messenger, allocator);
+	}
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"sync"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// DebugCallback receives messages reported through VK_EXT_debug_utils, with
+// the severity and message types decoded, such as "WARNING" and
+// "VALIDATION|PERFORMANCE".
+type DebugCallback func(severity string, msgType string, msg string)
+
+// WithDebugMessenger enables VK_EXT_debug_utils on the instance and routes
+// messages matching severity and msgType to callback, including the ones
+// emitted while the instance is created and destroyed.
+func WithDebugMessenger(severity vk.DebugUtilsMessageSeverityFlags, msgType vk.DebugUtilsMessageTypeFlags, callback DebugCallback) Option {
+	return func(c *deviceConfig) {
+		c.messengerSeverity = severity
+		c.messengerTypes = msgType
+		c.debugCallback = callback
+	}
+}
+
+// debugCallbacks maps the IDs passed to the C callback as user data to the
+// DebugCallback of each messenger, since Go pointers can't be kept by C.
+var debugCallbacks = struct {
+	sync.Mutex
+	next      uintptr
+	callbacks map[uintptr]DebugCallback
+}{callbacks: make(map[uintptr]DebugCallback)}
+
+//export goDebugUtilsMessage
+func goDebugUtilsMessage(severity, types C.uint32_t, idName, message *C.char, id C.uintptr_t) {
+	debugCallbacks.Lock()
+	callback := debugCallbacks.callbacks[uintptr(id)]
+	debugCallbacks.Unlock()
+	if callback == nil {
+		return
+	}
+	msg := C.GoString(message)
+	if idName != nil {
+		msg = fmt.Sprintf("[%s] %s", C.GoString(idName), msg)
+	}
+	callback(debugUtilsSeverity(vk.DebugUtilsMessageSeverityFlags(severity)),
+		debugUtilsTypes(vk.DebugUtilsMessageTypeFlags(types)), msg)
+}
+
+// registerDebugCallback stores callback and returns the ID to pass to the
+// C callback as user data. Release it with unregisterDebugCallback once no
+// messenger uses it.
+func registerDebugCallback(callback DebugCallback) uintptr {
+	debugCallbacks.Lock()
+	defer debugCallbacks.Unlock()
+	debugCallbacks.next++
+	debugCallbacks.callbacks[debugCallbacks.next] = callback
+	return debugCallbacks.next
+}
+
+func unregisterDebugCallback(id uintptr) {
+	debugCallbacks.Lock()
+	delete(debugCallbacks.callbacks, id)
+	debugCallbacks.Unlock()
+}
+
+// newInstanceDebugMessengerInfo returns a VkDebugUtilsMessengerCreateInfoEXT
+// in C memory for the pNext chain of VkInstanceCreateInfo, so messages from
+// vkCreateInstance and vkDestroyInstance reach the callback registered
+// under id. Release it with freeInstanceDebugMessengerInfo once the
+// instance is created.
+func newInstanceDebugMessengerInfo(severity vk.DebugUtilsMessageSeverityFlags, msgType vk.DebugUtilsMessageTypeFlags, id uintptr) unsafe.Pointer {
+	return unsafe.Pointer(C.new_debug_utils_messenger_create_info(C.uint32_t(severity), C.uint32_t(msgType), C.uintptr_t(id)))
+}
+
+func freeInstanceDebugMessengerInfo(info unsafe.Pointer) {
+	C.free(info)
+}
+
+// createDebugMessenger creates a VK_EXT_debug_utils messenger calling the
+// callback registered under id.
+func createDebugMessenger(instance vk.Instance, severity vk.DebugUtilsMessageSeverityFlags, msgType vk.DebugUtilsMessageTypeFlags,
+	id uintptr, allocator *vk.AllocationCallbacks) (uint64, error) {
+
+	var messenger C.uint64_t
+	var found C.int
+	ret := vk.Result(C.create_debug_utils_messenger(unsafe.Pointer(instance), C.uint32_t(severity), C.uint32_t(msgType),
+		C.uintptr_t(id), unsafe.Pointer(allocator), &messenger, &found))
+	if found == 0 {
+		err := fmt.Errorf("vkCreateDebugUtilsMessengerEXT not available")
+		return 0, err
+	}
+	if err := vk.Error(ret); err != nil {
+		err = fmt.Errorf("vkCreateDebugUtilsMessengerEXT failed with %s", err)
+		return 0, err
+	}
+	return uint64(messenger), nil
+}
+
+// destroyDebugMessenger destroys messenger. The callback it used stays
+// registered until unregisterDebugCallback.
+func destroyDebugMessenger(instance vk.Instance, messenger uint64, allocator *vk.AllocationCallbacks) {
+	C.destroy_debug_utils_messenger(unsafe.Pointer(instance), C.uint64_t(messenger), unsafe.Pointer(allocator))
+}
+
+var debugUtilsSeverityTable = []flagName{
+	{uint32(vk.DebugUtilsMessageSeverityVerboseBit), "VERBOSE"},
+	{uint32(vk.DebugUtilsMessageSeverityInfoBit), "INFO"},
+	{uint32(vk.DebugUtilsMessageSeverityWarningBit), "WARNING"},
+	{uint32(vk.DebugUtilsMessageSeverityErrorBit), "ERROR"},
+}
+
+var debugUtilsTypeTable = []flagName{
+	{uint32(vk.DebugUtilsMessageTypeGeneralBit), "GENERAL"},
+	{uint32(vk.DebugUtilsMessageTypeValidationBit), "VALIDATION"},
+	{uint32(vk.DebugUtilsMessageTypePerformanceBit), "PERFORMANCE"},
+}
+
+func debugUtilsSeverity(flags vk.DebugUtilsMessageSeverityFlags) string {
+	return joinFlags(flagNames(uint32(flags), debugUtilsSeverityTable))
+}
+
+func debugUtilsTypes(flags vk.DebugUtilsMessageTypeFlags) string {
+	return joinFlags(flagNames(uint32(flags), debugUtilsTypeTable))
+}
@@ -0,0 +1,51 @@
+package vulkandevice
+
+import (
+	"errors"
+	"strings"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// ErrNoSupportedDepthFormat is returned by FindSupportedDepthFormat when
+// the GPU supports none of the candidate formats as a depth attachment.
+var ErrNoSupportedDepthFormat = errors.New("no supported depth format")
+
+// depthFormats and depthStencilFormats are the candidates of
+// FindSupportedDepthFormat, most precise first.
+var (
+	depthFormats = []vk.Format{
+		vk.FormatD32Sfloat,
+		vk.FormatX8D24UnormPack32,
+		vk.FormatD16Unorm,
+	}
+	depthStencilFormats = []vk.Format{
+		vk.FormatD32SfloatS8Uint,
+		vk.FormatD24UnormS8Uint,
+		vk.FormatD16UnormS8Uint,
+	}
+)
+
+// FindSupportedDepthFormat returns the most precise depth format, with a
+// stencil component if withStencil is true, that gpu supports as an
+// optimally tiled depth/stencil attachment, for CreateImage and
+// CreateSimpleRenderPass.
+func FindSupportedDepthFormat(gpu vk.PhysicalDevice, withStencil bool) (vk.Format, error) {
+	candidates := depthFormats
+	if withStencil {
+		candidates = depthStencilFormats
+	}
+	for _, format := range candidates {
+		if SupportsFormat(gpu, format, vk.ImageTilingOptimal, vk.FormatFeatureFlags(vk.FormatFeatureDepthStencilAttachmentBit)) {
+			return format, nil
+		}
+	}
+	return vk.FormatUndefined, ErrNoSupportedDepthFormat
+}
+
+// HasStencilComponent reports whether format has a stencil component, such
+// as D24_UNORM_S8_UINT, so views and barriers of depth images in it must
+// include VK_IMAGE_ASPECT_STENCIL_BIT.
+func HasStencilComponent(format vk.Format) bool {
+	return strings.Contains(FormatName(format), "S8")
+}
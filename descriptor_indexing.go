@@ -0,0 +1,165 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// descriptorIndexingExtensionName provides descriptor indexing on devices
+// older than Vulkan 1.2.
+const descriptorIndexingExtensionName = "VK_EXT_descriptor_indexing"
+
+// Structure types of the descriptor indexing structures, which are newer
+// than the vulkan-go bindings. They stay valid on Vulkan 1.2 devices,
+// where the extension was promoted to core.
+const (
+	structureTypePhysicalDeviceDescriptorIndexingFeatures   vk.StructureType = 1000161001
+	structureTypePhysicalDeviceDescriptorIndexingProperties vk.StructureType = 1000161002
+)
+
+// descriptorIndexingFeatureNames lists the
+// VkPhysicalDeviceDescriptorIndexingFeatures fields in declaration order.
+var descriptorIndexingFeatureNames = []string{
+	"shaderInputAttachmentArrayDynamicIndexing",
+	"shaderUniformTexelBufferArrayDynamicIndexing",
+	"shaderStorageTexelBufferArrayDynamicIndexing",
+	"shaderUniformBufferArrayNonUniformIndexing",
+	"shaderSampledImageArrayNonUniformIndexing",
+	"shaderStorageBufferArrayNonUniformIndexing",
+	"shaderStorageImageArrayNonUniformIndexing",
+	"shaderInputAttachmentArrayNonUniformIndexing",
+	"shaderUniformTexelBufferArrayNonUniformIndexing",
+	"shaderStorageTexelBufferArrayNonUniformIndexing",
+	"descriptorBindingUniformBufferUpdateAfterBind",
+	"descriptorBindingSampledImageUpdateAfterBind",
+	"descriptorBindingStorageImageUpdateAfterBind",
+	"descriptorBindingStorageBufferUpdateAfterBind",
+	"descriptorBindingUniformTexelBufferUpdateAfterBind",
+	"descriptorBindingStorageTexelBufferUpdateAfterBind",
+	"descriptorBindingUpdateUnusedWhilePending",
+	"descriptorBindingPartiallyBound",
+	"descriptorBindingVariableDescriptorCount",
+	"runtimeDescriptorArray",
+}
+
+// descriptorIndexingPropertyNames lists the Bool32 fields of
+// VkPhysicalDeviceDescriptorIndexingProperties in declaration order.
+var descriptorIndexingPropertyNames = []string{
+	"shaderUniformBufferArrayNonUniformIndexingNative",
+	"shaderSampledImageArrayNonUniformIndexingNative",
+	"shaderStorageBufferArrayNonUniformIndexingNative",
+	"shaderStorageImageArrayNonUniformIndexingNative",
+	"shaderInputAttachmentArrayNonUniformIndexingNative",
+	"robustBufferAccessUpdateAfterBind",
+	"quadDivergentImplicitLod",
+}
+
+// descriptorIndexingLimitNames lists the update-after-bind limits of
+// VkPhysicalDeviceDescriptorIndexingProperties in declaration order.
+var descriptorIndexingLimitNames = []string{
+	"maxPerStageDescriptorUpdateAfterBindSamplers",
+	"maxPerStageDescriptorUpdateAfterBindUniformBuffers",
+	"maxPerStageDescriptorUpdateAfterBindStorageBuffers",
+	"maxPerStageDescriptorUpdateAfterBindSampledImages",
+	"maxPerStageDescriptorUpdateAfterBindStorageImages",
+	"maxPerStageDescriptorUpdateAfterBindInputAttachments",
+	"maxPerStageUpdateAfterBindResources",
+	"maxDescriptorSetUpdateAfterBindSamplers",
+	"maxDescriptorSetUpdateAfterBindUniformBuffers",
+	"maxDescriptorSetUpdateAfterBindUniformBuffersDynamic",
+	"maxDescriptorSetUpdateAfterBindStorageBuffers",
+	"maxDescriptorSetUpdateAfterBindStorageBuffersDynamic",
+	"maxDescriptorSetUpdateAfterBindSampledImages",
+	"maxDescriptorSetUpdateAfterBindStorageImages",
+	"maxDescriptorSetUpdateAfterBindInputAttachments",
+}
+
+// physicalDeviceDescriptorIndexingFeatures has the C layout of
+// VkPhysicalDeviceDescriptorIndexingFeatures.
+type physicalDeviceDescriptorIndexingFeatures struct {
+	chainHeader
+	Features [20]vk.Bool32
+}
+
+// physicalDeviceDescriptorIndexingProperties has the C layout of
+// VkPhysicalDeviceDescriptorIndexingProperties.
+type physicalDeviceDescriptorIndexingProperties struct {
+	chainHeader
+	MaxUpdateAfterBindDescriptorsInAllPools uint32
+	Properties                              [7]vk.Bool32
+	Limits                                  [15]uint32
+}
+
+// DescriptorIndexingInfo holds the descriptor indexing (bindless) support
+// of a GPU, keyed by field name.
+type DescriptorIndexingInfo struct {
+	MaxUpdateAfterBindDescriptorsInAllPools uint32            `json:"max_update_after_bind_descriptors_in_all_pools"`
+	Limits                                  map[string]uint32 `json:"limits"`
+	// Properties are the Bool32 properties, such as
+	// shaderSampledImageArrayNonUniformIndexingNative.
+	Properties map[string]bool `json:"properties"`
+	Features   map[string]bool `json:"features"`
+}
+
+// GetDescriptorIndexingInfo queries the descriptor indexing limits,
+// properties and features of the GPU at gpuIndex, which must support
+// Vulkan 1.2 or VK_EXT_descriptor_indexing, otherwise it fails with
+// ErrExtensionNotSupported.
+func GetDescriptorIndexingInfo(v *VulkanDeviceInfo, gpuIndex int) (DescriptorIndexingInfo, error) {
+	var info DescriptorIndexingInfo
+	gpu := v.gpuDevices[gpuIndex]
+	if getDeviceProperties(gpu).ApiVersion < vk.MakeVersion(1, 2, 0) {
+		extensions, err := EnumerateDeviceExtensions(gpu)
+		if err != nil {
+			return info, err
+		}
+		if !hasExtension(extensions, descriptorIndexingExtensionName) {
+			err := fmt.Errorf("%w: %s", ErrExtensionNotSupported, descriptorIndexingExtensionName)
+			return info, err
+		}
+	}
+	properties := physicalDeviceDescriptorIndexingProperties{chainHeader: chainHeader{SType: structureTypePhysicalDeviceDescriptorIndexingProperties}}
+	features := physicalDeviceDescriptorIndexingFeatures{chainHeader: chainHeader{SType: structureTypePhysicalDeviceDescriptorIndexingFeatures}}
+	if _, err := GetPhysicalDeviceProperties2(v, gpuIndex, ChainedStruct{unsafe.Pointer(&properties), unsafe.Sizeof(properties)}); err != nil {
+		return info, err
+	}
+	if _, err := GetPhysicalDeviceFeatures2(v, gpuIndex, ChainedStruct{unsafe.Pointer(&features), unsafe.Sizeof(features)}); err != nil {
+		return info, err
+	}
+	info.MaxUpdateAfterBindDescriptorsInAllPools = properties.MaxUpdateAfterBindDescriptorsInAllPools
+	info.Limits = make(map[string]uint32, len(descriptorIndexingLimitNames))
+	for i, name := range descriptorIndexingLimitNames {
+		info.Limits[name] = properties.Limits[i]
+	}
+	info.Properties = featureMap(descriptorIndexingPropertyNames, properties.Properties[:])
+	info.Features = featureMap(descriptorIndexingFeatureNames, features.Features[:])
+	return info, nil
+}
+
+// PrintDescriptorIndexing prints the update-after-bind limits, the native
+// non-uniform indexing properties and the descriptor indexing features of
+// the GPU at gpuIndex, for bindless renderers.
+func PrintDescriptorIndexing(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Descriptor Indexing", gpuIndex))
+	info, err := GetDescriptorIndexingInfo(v, gpuIndex)
+	if err != nil {
+		table.AddRow("Descriptor Indexing", err)
+		fmt.Fprintln(w, "\n"+table.Render())
+		return
+	}
+	addSection(table, "Limits")
+	table.AddRow("maxUpdateAfterBindDescriptorsInAllPools", info.MaxUpdateAfterBindDescriptorsInAllPools)
+	for _, name := range descriptorIndexingLimitNames {
+		table.AddRow(name, info.Limits[name])
+	}
+	addFeatureSection(table, "Properties", descriptorIndexingPropertyNames, info.Properties)
+	addFeatureSection(table, "Features", descriptorIndexingFeatureNames, info.Features)
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
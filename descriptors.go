@@ -0,0 +1,110 @@
+package vulkandevice
+
+import (
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// CreateDescriptorPool creates a descriptor pool for up to maxSets sets
+// holding the descriptors of poolSizes. Sets are released together by
+// vk.ResetDescriptorPool or by destroying the pool with
+// vk.DestroyDescriptorPool.
+func CreateDescriptorPool(v *VulkanDeviceInfo, maxSets uint32, poolSizes []vk.DescriptorPoolSize) (vk.DescriptorPool, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return vk.NullDescriptorPool, ErrNoDevice
+	}
+	createInfo := &vk.DescriptorPoolCreateInfo{
+		SType:         vk.StructureTypeDescriptorPoolCreateInfo,
+		MaxSets:       maxSets,
+		PoolSizeCount: uint32(len(poolSizes)),
+		PPoolSizes:    poolSizes,
+	}
+	var pool vk.DescriptorPool
+	if err := vk.Error(vk.CreateDescriptorPool(v.device, createInfo, v.allocator, &pool)); err != nil {
+		err = fmt.Errorf("vkCreateDescriptorPool failed with %s", err)
+		return vk.NullDescriptorPool, err
+	}
+	return pool, nil
+}
+
+// AllocateDescriptorSets allocates one descriptor set from pool per layout
+// of layouts, in the same order.
+func AllocateDescriptorSets(v *VulkanDeviceInfo, pool vk.DescriptorPool, layouts []vk.DescriptorSetLayout) ([]vk.DescriptorSet, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return nil, ErrNoDevice
+	}
+	if len(layouts) == 0 {
+		return nil, nil
+	}
+	allocateInfo := &vk.DescriptorSetAllocateInfo{
+		SType:              vk.StructureTypeDescriptorSetAllocateInfo,
+		DescriptorPool:     pool,
+		DescriptorSetCount: uint32(len(layouts)),
+		PSetLayouts:        layouts,
+	}
+	sets := make([]vk.DescriptorSet, len(layouts))
+	if err := vk.Error(vk.AllocateDescriptorSets(v.device, allocateInfo, &sets[0])); err != nil {
+		err = fmt.Errorf("vkAllocateDescriptorSets failed with %s", err)
+		return nil, err
+	}
+	return sets, nil
+}
+
+// UpdateDescriptorSetBuffer points binding of set at size bytes of buf from
+// offset, as a descriptor of descriptorType, such as
+// vk.DescriptorTypeStorageBuffer. Pass vk.WholeSize as size for the rest of
+// the buffer.
+func UpdateDescriptorSetBuffer(v *VulkanDeviceInfo, set vk.DescriptorSet, binding uint32, buf vk.Buffer,
+	offset, size vk.DeviceSize, descriptorType vk.DescriptorType) {
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return
+	}
+	writes := []vk.WriteDescriptorSet{{
+		SType:           vk.StructureTypeWriteDescriptorSet,
+		DstSet:          set,
+		DstBinding:      binding,
+		DescriptorCount: 1,
+		DescriptorType:  descriptorType,
+		PBufferInfo: []vk.DescriptorBufferInfo{{
+			Buffer: buf,
+			Offset: offset,
+			Range:  size,
+		}},
+	}}
+	vk.UpdateDescriptorSets(v.device, 1, writes, 0, nil)
+}
+
+// UpdateDescriptorSetImage points binding of set at view, in layout, as a
+// descriptor of descriptorType, such as vk.DescriptorTypeCombinedImageSampler.
+// sampler is ignored unless the type uses one, and may be vk.NullSampler
+// otherwise.
+func UpdateDescriptorSetImage(v *VulkanDeviceInfo, set vk.DescriptorSet, binding uint32, view vk.ImageView,
+	sampler vk.Sampler, layout vk.ImageLayout, descriptorType vk.DescriptorType) {
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return
+	}
+	writes := []vk.WriteDescriptorSet{{
+		SType:           vk.StructureTypeWriteDescriptorSet,
+		DstSet:          set,
+		DstBinding:      binding,
+		DescriptorCount: 1,
+		DescriptorType:  descriptorType,
+		PImageInfo: []vk.DescriptorImageInfo{{
+			Sampler:     sampler,
+			ImageView:   view,
+			ImageLayout: layout,
+		}},
+	}}
+	vk.UpdateDescriptorSets(v.device, 1, writes, 0, nil)
+}
@@ -0,0 +1,785 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// VulkanDeviceInfo holds a Vulkan instance, the physical devices it reports
+// and a logical device created on one of them.
+//
+// Its methods are safe for concurrent use: queries take a read lock and
+// Destroy, Recreate and WaitIdle take the write lock. Functions creating or
+// using objects on the logical device, such as CreateBuffer, CreateFence
+// and QueueSubmit, take the read lock too, so Destroy waits for them.
+// Reporting functions that take a *VulkanDeviceInfo, such as PrintInfo,
+// don't lock and must not run concurrently with Destroy or Recreate.
+type VulkanDeviceInfo struct {
+	mu sync.RWMutex
+
+	gpuDevices       []vk.PhysicalDevice
+	gpuIndex         int
+	queueFamilies    [][]vk.QueueFamilyProperties
+	queueFamilyIndex uint32
+	// queues holds the device queues, indexed by queue family.
+	queues map[uint32][]vk.Queue
+
+	// presentationEnabled reports whether VK_KHR_swapchain was enabled on the device.
+	presentationEnabled bool
+	// requestedExtensions lists the device extensions NewVulkanDevice asked for.
+	requestedExtensions []string
+
+	instanceLayers     []vk.LayerProperties
+	instanceExtensions []vk.ExtensionProperties
+	// enabledInstanceExtensions lists the extensions the instance was
+	// created with.
+	enabledInstanceExtensions []string
+	// loaderVersion is the instance version supported by the loader and
+	// apiVersion the one the instance was created with.
+	loaderVersion uint32
+	apiVersion    uint32
+	// properties2KHR reports whether the *2KHR query entry points of
+	// VK_KHR_get_physical_device_properties2 are used on a 1.0 instance.
+	properties2KHR bool
+
+	allocator     *vk.AllocationCallbacks
+	instance      vk.Instance
+	debugCallback vk.DebugReportCallback
+	surface       vk.Surface
+	device        vk.Device
+
+	// logger receives diagnostic output, see WithLogger.
+	logger Logger
+
+	// timelineSemaphores is set when the device was created with the
+	// timelineSemaphore feature, and timelineSemaphoresKHR when it was
+	// enabled through VK_KHR_timeline_semaphore.
+	timelineSemaphores    bool
+	timelineSemaphoresKHR bool
+
+	// debugMessenger is the VK_EXT_debug_utils messenger handle and
+	// debugMessengerID the key of its DebugCallback in debugCallbacks.
+	debugMessenger   uint64
+	debugMessengerID uintptr
+
+	// appInfo, window and opts are the NewVulkanDevice arguments, kept so
+	// Recreate can run it again.
+	appInfo *vk.ApplicationInfo
+	window  uintptr
+	opts    []Option
+
+	// OnDeviceLost, if set, is called by Recreate before the device is
+	// torn down, e.g. after a call returned VK_ERROR_DEVICE_LOST. It is
+	// called without the lock held.
+	OnDeviceLost func()
+}
+
+// Destroy releases the device, surface and instance. Handles that were never
+// created are skipped, and calling Destroy again is a no-op.
+func (v *VulkanDeviceInfo) Destroy() {
+	if v == nil {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.destroy()
+}
+
+// destroy is Destroy for callers that hold v.mu.
+func (v *VulkanDeviceInfo) destroy() {
+	v.gpuDevices = nil
+	v.gpuIndex = 0
+	v.queueFamilies = nil
+	v.queueFamilyIndex = 0
+	v.queues = nil
+	v.presentationEnabled = false
+	v.requestedExtensions = nil
+	v.instanceLayers = nil
+	v.instanceExtensions = nil
+	v.enabledInstanceExtensions = nil
+	v.properties2KHR = false
+	v.timelineSemaphores, v.timelineSemaphoresKHR = false, false
+	if v.device != nil {
+		vk.DeviceWaitIdle(v.device)
+		vk.DestroyDevice(v.device, v.allocator)
+		v.device = nil
+	}
+	if v.surface != vk.NullSurface {
+		vk.DestroySurface(v.instance, v.surface, v.allocator)
+		v.surface = vk.NullSurface
+	}
+	v.destroyInstance()
+}
+
+// Recreate destroys the instance and device and creates them again with
+// the arguments originally passed to NewVulkanDevice. It is meant for
+// recovering from VK_ERROR_DEVICE_LOST and may be called on a partially
+// destroyed VulkanDeviceInfo. If creation fails, v is left destroyed.
+func (v *VulkanDeviceInfo) Recreate() error {
+	if v.OnDeviceLost != nil {
+		v.OnDeviceLost()
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.appInfo == nil {
+		err := fmt.Errorf("Recreate: device was not created by NewVulkanDevice")
+		return err
+	}
+	v.destroy()
+	return v.create(v.appInfo, v.window, v.opts)
+}
+
+// WaitIdle waits for the logical device to finish all submitted work.
+func (v *VulkanDeviceInfo) WaitIdle() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.device == nil {
+		return nil
+	}
+	if err := vk.Error(vk.DeviceWaitIdle(v.device)); err != nil {
+		err = fmt.Errorf("vkDeviceWaitIdle failed with %s", err)
+		return err
+	}
+	return nil
+}
+
+// GPUIndex returns the index of the GPU the logical device was created on.
+func (v *VulkanDeviceInfo) GPUIndex() int {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.gpuIndex
+}
+
+// Instance returns the Vulkan instance and the allocation callbacks it was
+// created with, for creating objects such as window surfaces with other
+// libraries.
+func (v *VulkanDeviceInfo) Instance() (vk.Instance, *vk.AllocationCallbacks) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.instance, v.allocator
+}
+
+// DeviceSummary is the one-line description of a GPU returned by
+// ListDevices.
+type DeviceSummary struct {
+	Index         int
+	Name          string
+	VendorID      uint32
+	DeviceType    string
+	APIVersion    string
+	DriverVersion string
+	// TotalVRAMMiB is the size of the device-local heaps in MiB.
+	TotalVRAMMiB uint64
+}
+
+// ListDevices summarizes every GPU enumerated by the instance, in
+// enumeration order.
+func (v *VulkanDeviceInfo) ListDevices() ([]DeviceSummary, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.instance == nil {
+		err := fmt.Errorf("ListDevices: device was destroyed")
+		return nil, err
+	}
+	devices := make([]DeviceSummary, 0, len(v.gpuDevices))
+	for i, gpu := range v.gpuDevices {
+		gpuProperties := getDeviceProperties(gpu)
+		devices = append(devices, DeviceSummary{
+			Index:         i,
+			Name:          vk.ToString(gpuProperties.DeviceName[:]),
+			VendorID:      gpuProperties.VendorID,
+			DeviceType:    DeviceTypeName(gpuProperties.DeviceType),
+			APIVersion:    vk.Version(gpuProperties.ApiVersion).String(),
+			DriverVersion: formatDriverVersion(gpuProperties.VendorID, gpuProperties.DriverVersion),
+			TotalVRAMMiB:  deviceLocalMemory(gpu) >> 20,
+		})
+	}
+	return devices, nil
+}
+
+// QueueFamilyIndex returns the queue family the logical device's queue was
+// created from, for use with vk.GetDeviceQueue.
+func (v *VulkanDeviceInfo) QueueFamilyIndex() uint32 {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.queueFamilyIndex
+}
+
+// GetQueue returns queue queueIndex of queue family familyIndex, as created
+// by WithQueue, WithQueueFamilies or the default single queue.
+func (v *VulkanDeviceInfo) GetQueue(familyIndex, queueIndex uint32) (vk.Queue, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	queues, ok := v.queues[familyIndex]
+	if !ok || queueIndex >= uint32(len(queues)) {
+		err := fmt.Errorf("%w: family %d queue %d", ErrQueueNotFound, familyIndex, queueIndex)
+		return nil, err
+	}
+	return queues[queueIndex], nil
+}
+
+func (v *VulkanDeviceInfo) destroyInstance() {
+	if v.debugMessenger != 0 {
+		destroyDebugMessenger(v.instance, v.debugMessenger, v.allocator)
+		v.debugMessenger = 0
+	}
+	if v.debugCallback != vk.NullDebugReportCallback {
+		vk.DestroyDebugReportCallback(v.instance, v.debugCallback, v.allocator)
+		v.debugCallback = vk.NullDebugReportCallback
+	}
+	if v.instance != nil {
+		vk.DestroyInstance(v.instance, v.allocator)
+		v.instance = nil
+	}
+	// The instance's own messenger may report from vkDestroyInstance.
+	if v.debugMessengerID != 0 {
+		unregisterDebugCallback(v.debugMessengerID)
+		v.debugMessengerID = 0
+	}
+}
+
+// NewVulkanDevice creates a Vulkan instance described by appInfo and a
+// logical device on one of the GPUs it enumerates, customized by opts. An
+// appInfo.ApiVersion of zero picks the newest version the loader supports,
+// as does a nil appInfo. Call Destroy to release it.
+func NewVulkanDevice(appInfo *vk.ApplicationInfo, window uintptr, opts ...Option) (*VulkanDeviceInfo, error) {
+	v := &VulkanDeviceInfo{}
+	if err := v.create(appInfo, window, opts); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// create runs the NewVulkanDevice flow on v, which must be new or destroyed.
+// The caller must hold v.mu or be the only user of v.
+func (v *VulkanDeviceInfo) create(appInfo *vk.ApplicationInfo, window uintptr, opts []Option) error {
+	config := &deviceConfig{
+		queueFlags: vk.QueueFlags(vk.QueueGraphicsBit | vk.QueueComputeBit),
+		gpuIndex:   -1,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+	v.allocator = config.allocator
+	v.logger = config.logger
+	if v.logger == nil {
+		v.logger = discardLogger{}
+	}
+	if config.appInfo != nil {
+		appInfo = config.appInfo
+	} else if appInfo == nil {
+		appInfo = &vk.ApplicationInfo{SType: vk.StructureTypeApplicationInfo}
+	}
+	v.appInfo = appInfo
+	v.window = window
+	v.opts = opts
+
+	// step 1: create a Vulkan instance.
+	var err error
+	v.instanceLayers, err = EnumerateLayers()
+	if err != nil && !errors.Is(err, ErrNoLayersAvailable) {
+		return err
+	}
+	if v.instanceExtensions, err = EnumerateInstanceExtensions(); err != nil {
+		return err
+	}
+	if missing := missingExtensions(v.instanceExtensions, config.instanceExtensions); len(missing) > 0 {
+		err = fmt.Errorf("%w: instance extensions %s", ErrExtensionNotSupported, strings.Join(missing, ", "))
+		return err
+	}
+	instanceExtensions := append([]string{}, config.instanceExtensions...)
+	var missingLayers []string
+	for _, name := range config.layers {
+		if !hasLayer(v.instanceLayers, name) {
+			missingLayers = append(missingLayers, vk.ToString([]byte(name)))
+		}
+	}
+	if len(missingLayers) > 0 {
+		err = fmt.Errorf("%w: %s", ErrLayerNotAvailable, strings.Join(missingLayers, ", "))
+		return err
+	}
+	instanceLayers := append([]string{}, config.layers...)
+	if config.validation && !containsExtension(instanceLayers, validationLayerName) {
+		if !hasLayer(v.instanceLayers, validationLayerName) {
+			return ErrValidationLayerNotAvailable
+		}
+		instanceLayers = append(instanceLayers, validationLayerName+"\x00")
+	}
+	if config.logFunc != nil {
+		instanceExtensions = append(instanceExtensions, vk.ExtDebugReportExtensionName+"\x00")
+	}
+	if config.debugCallback != nil {
+		instanceExtensions = append(instanceExtensions, vk.ExtDebugUtilsExtensionName+"\x00")
+	}
+	for _, name := range platformSurfaceExtensions {
+		if hasExtension(v.instanceExtensions, name) && !containsExtension(instanceExtensions, name) {
+			instanceExtensions = append(instanceExtensions, name+"\x00")
+		}
+	}
+	if v.loaderVersion, err = EnumerateInstanceVersion(); err != nil {
+		return err
+	}
+	// An ApiVersion of zero asks for the highest version the loader and
+	// the bindings both support.
+	instanceAppInfo := *appInfo
+	if instanceAppInfo.ApiVersion == 0 {
+		instanceAppInfo.ApiVersion = negotiateAPIVersion(v.loaderVersion)
+	}
+	v.apiVersion = instanceAppInfo.ApiVersion
+	// Vulkan 1.0 instances need VK_KHR_get_physical_device_properties2 for
+	// extension queries such as the memory budget.
+	if v.apiVersion < vk.MakeVersion(1, 1, 0) && hasExtension(v.instanceExtensions, properties2ExtensionName) {
+		instanceExtensions = append(instanceExtensions, properties2ExtensionName+"\x00")
+		v.properties2KHR = true
+	}
+	// MoltenVK and other portability implementations are only enumerated
+	// when asked for.
+	var instanceFlags vk.InstanceCreateFlags
+	if hasExtension(v.instanceExtensions, portabilityEnumerationExtensionName) {
+		instanceExtensions = append(instanceExtensions, portabilityEnumerationExtensionName+"\x00")
+		instanceFlags |= instanceCreateEnumeratePortability
+	}
+	// VK_KHR_display depends on VK_KHR_surface, which isn't enabled on
+	// platforms without a supported window system.
+	if config.displays && hasExtension(v.instanceExtensions, displayExtensionName) &&
+		!containsExtension(instanceExtensions, displayExtensionName) {
+		if !containsExtension(instanceExtensions, vk.KhrSurfaceExtensionName) {
+			instanceExtensions = append(instanceExtensions, vk.KhrSurfaceExtensionName+"\x00")
+		}
+		instanceExtensions = append(instanceExtensions, displayExtensionName+"\x00")
+	}
+	instanceCreateInfo := &vk.InstanceCreateInfo{
+		SType:                   vk.StructureTypeInstanceCreateInfo,
+		Flags:                   instanceFlags,
+		PApplicationInfo:        &instanceAppInfo,
+		EnabledExtensionCount:   uint32(len(instanceExtensions)),
+		PpEnabledExtensionNames: instanceExtensions,
+		EnabledLayerCount:       uint32(len(instanceLayers)),
+		PpEnabledLayerNames:     instanceLayers,
+	}
+	if config.debugCallback != nil {
+		v.debugMessengerID = registerDebugCallback(config.debugCallback)
+		messengerInfo := newInstanceDebugMessengerInfo(config.messengerSeverity, config.messengerTypes, v.debugMessengerID)
+		defer freeInstanceDebugMessengerInfo(messengerInfo)
+		instanceCreateInfo.PNext = messengerInfo
+	}
+	err = vk.Error(vk.CreateInstance(instanceCreateInfo, v.allocator, &v.instance))
+	if err != nil {
+		v.destroyInstance()
+		err = fmt.Errorf("vkCreateInstance failed with %s", err)
+		return err
+	} else {
+		vk.InitInstance(v.instance)
+	}
+	for _, name := range instanceExtensions {
+		v.enabledInstanceExtensions = append(v.enabledInstanceExtensions, vk.ToString([]byte(name)))
+	}
+	v.logger.Debug("created Vulkan instance", "api_version", vk.Version(v.apiVersion),
+		"extensions", strings.Join(v.enabledInstanceExtensions, ","))
+
+	if config.logFunc != nil {
+		if v.debugCallback, err = createDebugReportCallback(v.instance, config.logFunc, v.allocator); err != nil {
+			v.destroy()
+			return err
+		}
+	}
+
+	if config.debugCallback != nil {
+		v.debugMessenger, err = createDebugMessenger(v.instance, config.messengerSeverity,
+			config.messengerTypes, v.debugMessengerID, v.allocator)
+		if err != nil {
+			v.destroy()
+			return err
+		}
+	}
+
+	if v.gpuDevices, err = getPhysicalDevices(v.instance); err != nil {
+		v.destroy()
+		return err
+	}
+	for _, gpu := range v.gpuDevices {
+		v.queueFamilies = append(v.queueFamilies, GetQueueFamilyProperties(gpu))
+	}
+	if config.gpuIndex >= 0 {
+		if err = checkPhysicalDeviceIndex(v.gpuDevices, config.gpuIndex); err != nil {
+			v.destroy()
+			return err
+		}
+		v.gpuIndex = config.gpuIndex
+	} else if config.deviceName != "" {
+		if v.gpuIndex, err = findPhysicalDeviceByName(v.gpuDevices, config.deviceName); err != nil {
+			v.destroy()
+			return err
+		}
+	} else if config.vendorID != 0 {
+		selector := config.selector
+		if selector == nil {
+			selector = PreferDiscreteGPU
+		}
+		if v.gpuIndex, err = findPhysicalDeviceByVendor(v.gpuDevices, config.vendorID, selector); err != nil {
+			v.destroy()
+			return err
+		}
+	} else if config.selector != nil {
+		if v.gpuIndex, err = selectPhysicalDevice(v.gpuDevices, config.selector); err != nil {
+			v.destroy()
+			return err
+		}
+	}
+
+	if config.noDevice {
+		v.logger.Debug("skipping logical device creation", "gpu", v.gpuIndex)
+		return nil
+	}
+
+	// step 2: create a logical device from the selected GPU.
+	families, err := resolveQueueRequests(v.queueFamilies[v.gpuIndex], config.queueFamilies, config.queues)
+	if err != nil {
+		v.destroy()
+		return err
+	}
+	if len(families) == 0 {
+		v.queueFamilyIndex, err = findQueueFamily(v.queueFamilies[v.gpuIndex], config.queueFlags)
+		if err != nil {
+			v.destroy()
+			return err
+		}
+		families = []QueueFamilyRequest{{FamilyIndex: v.queueFamilyIndex, Priorities: []float32{1.0}}}
+	} else {
+		if err = checkQueueFamilyRequests(v.queueFamilies[v.gpuIndex], families); err != nil {
+			v.destroy()
+			return err
+		}
+		v.queueFamilyIndex = families[0].FamilyIndex
+	}
+	queueCreateInfos := make([]vk.DeviceQueueCreateInfo, len(families))
+	for i, family := range families {
+		queueCreateInfos[i] = vk.DeviceQueueCreateInfo{
+			SType:            vk.StructureTypeDeviceQueueCreateInfo,
+			QueueFamilyIndex: family.FamilyIndex,
+			QueueCount:       uint32(len(family.Priorities)),
+			PQueuePriorities: family.Priorities,
+		}
+	}
+	availableExtensions, err := EnumerateDeviceExtensions(v.gpuDevices[v.gpuIndex])
+	if err != nil {
+		v.destroy()
+		return err
+	}
+	if missing := missingExtensions(availableExtensions, config.deviceExtensions); len(missing) > 0 {
+		v.destroy()
+		err = fmt.Errorf("%w: device extensions %s", ErrExtensionNotSupported, strings.Join(missing, ", "))
+		return err
+	}
+	var deviceExtensions []string
+	if hasExtension(availableExtensions, vk.KhrSwapchainExtensionName) {
+		deviceExtensions = append(deviceExtensions, vk.KhrSwapchainExtensionName+"\x00")
+		v.requestedExtensions = append(v.requestedExtensions, vk.KhrSwapchainExtensionName)
+		v.presentationEnabled = true
+	} else {
+		v.logger.Warn("GPU does not support VK_KHR_swapchain, presentation is disabled", "gpu", v.gpuIndex)
+	}
+	if hasExtension(availableExtensions, portabilitySubsetExtensionName) {
+		deviceExtensions = append(deviceExtensions, portabilitySubsetExtensionName+"\x00")
+	}
+	for _, name := range config.deviceExtensions {
+		if containsExtension(deviceExtensions, vk.ToString([]byte(name))) {
+			continue
+		}
+		deviceExtensions = append(deviceExtensions, name)
+		v.requestedExtensions = append(v.requestedExtensions, vk.ToString([]byte(name)))
+	}
+	// Features beyond Vulkan 1.0 are enabled through the pNext chain.
+	var featureChain structChain
+	defer featureChain.free()
+	if config.timelineSemaphores {
+		features, extension, err := timelineSemaphoreFeatures(v, v.gpuIndex, availableExtensions)
+		if err != nil {
+			v.destroy()
+			return err
+		}
+		if extension != "" && !containsExtension(deviceExtensions, extension) {
+			deviceExtensions = append(deviceExtensions, extension+"\x00")
+		}
+		featureChain.add(unsafe.Pointer(&features), unsafe.Sizeof(features))
+	}
+	deviceCreateInfo := &vk.DeviceCreateInfo{
+		SType:                   vk.StructureTypeDeviceCreateInfo,
+		PNext:                   featureChain.link(),
+		QueueCreateInfoCount:    uint32(len(queueCreateInfos)),
+		PQueueCreateInfos:       queueCreateInfos,
+		EnabledExtensionCount:   uint32(len(deviceExtensions)),
+		PpEnabledExtensionNames: deviceExtensions,
+	}
+	var device vk.Device
+	err = vk.Error(vk.CreateDevice(v.gpuDevices[v.gpuIndex], deviceCreateInfo, v.allocator, &device))
+	if err != nil {
+		v.destroy()
+		err = fmt.Errorf("vkCreateDevice failed with %s", err)
+		return err
+	} else {
+		v.device = device
+	}
+	if config.timelineSemaphores {
+		v.timelineSemaphores = true
+		v.timelineSemaphoresKHR = containsExtension(deviceExtensions, timelineSemaphoreExtensionName)
+	}
+	v.logger.Debug("created logical device", "gpu", v.gpuIndex, "queue_family", v.queueFamilyIndex)
+	v.queues = make(map[uint32][]vk.Queue, len(families))
+	for _, family := range families {
+		queues := make([]vk.Queue, len(family.Priorities))
+		for i := range queues {
+			vk.GetDeviceQueue(device, family.FamilyIndex, uint32(i), &queues[i])
+		}
+		v.queues[family.FamilyIndex] = queues
+	}
+
+	return nil
+}
+
+// PrintInfo renders the DeviceReport of the selected GPU as a table, along
+// with the instance versions.
+func PrintInfo(w io.Writer, v *VulkanDeviceInfo) {
+	report := newDeviceReport(v, v.gpuIndex)
+
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(report.Name)
+	table.AddRow("Physical GPUs", len(v.gpuDevices))
+	addSelectedGPURow(table, v)
+	addInstanceVersionRows(table, v)
+	addReportRows(table, report)
+	addPresentationRow(table, v)
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
+
+// PrintAllDevices prints a summary of the GPU count followed by a numbered
+// table for every enumerated GPU. Each of sections, such as
+// PrintQueueFamilies and PrintMemoryInfo, is printed after the table of
+// every GPU.
+func PrintAllDevices(w io.Writer, v *VulkanDeviceInfo, sections ...func(w io.Writer, v *VulkanDeviceInfo, gpuIndex int)) {
+	summary := tablewriter.CreateTable()
+	summary.UTF8Box()
+	summary.AddRow("Physical GPUs", len(v.gpuDevices))
+	addSelectedGPURow(summary, v)
+	addInstanceVersionRows(summary, v)
+	fmt.Fprintln(w, "\n"+summary.Render())
+	PrintInstanceInfo(w, v)
+
+	for i := range v.gpuDevices {
+		report := newDeviceReport(v, i)
+
+		table := tablewriter.CreateTable()
+		table.UTF8Box()
+		table.AddTitle(fmt.Sprintf("GPU %d: %s", i, report.Name))
+		table.AddRow("Device Index", i)
+		addReportRows(table, report)
+		if i == v.gpuIndex {
+			addPresentationRow(table, v)
+		}
+
+		fmt.Fprintln(w, "\n"+table.Render())
+		for _, section := range sections {
+			section(w, v, i)
+		}
+	}
+}
+
+// addSelectedGPURow reports the GPU chosen by NewVulkanDevice, such as
+// "GPU 1 of 2".
+func addSelectedGPURow(table *tablewriter.Table, v *VulkanDeviceInfo) {
+	table.AddRow("Selected GPU", fmt.Sprintf("GPU %d of %d", v.gpuIndex, len(v.gpuDevices)))
+}
+
+// addInstanceVersionRows reports the instance version supported by the
+// loader next to the one the instance was created with.
+func addInstanceVersionRows(table *tablewriter.Table, v *VulkanDeviceInfo) {
+	table.AddRow("Loader Instance Version", vk.Version(v.loaderVersion))
+	table.AddRow("Instance API Version", vk.Version(v.apiVersion))
+}
+
+// addReportRows adds the identification rows of a DeviceReport to table,
+// skipping the fields the GPU doesn't report.
+func addReportRows(table *tablewriter.Table, report DeviceReport) {
+	table.AddRow("Physical Device Vendor", formatVendor(report.VendorID))
+	if report.DeviceType != DeviceTypeName(vk.PhysicalDeviceTypeOther) {
+		table.AddRow("Physical Device Type", report.DeviceType)
+	}
+	table.AddRow("API Version", report.APIVersion.Version)
+	table.AddRow("Driver Version", report.DriverVersion.Version)
+	if report.DriverID != "" {
+		table.AddRow("Driver ID", report.DriverID)
+		table.AddRow("Driver Name", report.DriverName)
+		table.AddRow("Driver Info", report.DriverInfo)
+		table.AddRow("Conformance Version", report.ConformanceVersion)
+	}
+	if report.DeviceUUID != "" {
+		table.AddRow("Device UUID", report.DeviceUUID)
+		table.AddRow("Driver UUID", report.DriverUUID)
+		if report.DeviceLUID != "" {
+			table.AddRow("Device LUID", report.DeviceLUID)
+		}
+		table.AddRow("Device Node Mask", fmt.Sprintf("0x%x", report.DeviceNodeMask))
+	}
+	if report.PCIBusInfo != nil {
+		table.AddRow("PCI Address", report.PCIBusInfo.Address)
+	}
+	if report.PortabilitySubset {
+		table.AddRow("Portability Subset", "Yes, translated implementation such as MoltenVK")
+	}
+}
+
+// addSection starts a titled group of rows in a two-column table.
+func addSection(table *tablewriter.Table, name string) {
+	table.AddSeparator()
+	table.AddRow(name, "")
+}
+
+// ErrNoDevice is returned by the functions creating device objects when v
+// has no logical device, because of WithoutLogicalDevice or Destroy.
+var ErrNoDevice = errors.New("no logical device")
+
+// ErrNoPhysicalDevices is returned when the Vulkan loader reports no GPUs.
+var ErrNoPhysicalDevices = errors.New("getPhysicalDevice: no GPUs found on the system")
+
+func getPhysicalDevices(instance vk.Instance) ([]vk.PhysicalDevice, error) {
+	var gpuCount uint32
+	err := vk.Error(vk.EnumeratePhysicalDevices(instance, &gpuCount, nil))
+	if err != nil {
+		err = fmt.Errorf("vkEnumeratePhysicalDevices failed with %s", err)
+		return nil, err
+	}
+	if gpuCount == 0 {
+		return nil, ErrNoPhysicalDevices
+	}
+	gpuList := make([]vk.PhysicalDevice, gpuCount)
+	err = vk.Error(vk.EnumeratePhysicalDevices(instance, &gpuCount, gpuList))
+	if err != nil {
+		err = fmt.Errorf("vkEnumeratePhysicalDevices failed with %s", err)
+		return nil, err
+	}
+	return gpuList, nil
+}
+
+// addPresentationRow warns when the logical device was created without
+// VK_KHR_swapchain.
+func addPresentationRow(table *tablewriter.Table, v *VulkanDeviceInfo) {
+	if v.device == nil {
+		table.AddRow("Logical Device", "Not created")
+	} else if !v.presentationEnabled {
+		table.AddRow("Warning", "VK_KHR_swapchain not supported, presentation disabled")
+	}
+}
+
+func getDeviceProperties(gpu vk.PhysicalDevice) vk.PhysicalDeviceProperties {
+	var gpuProperties vk.PhysicalDeviceProperties
+	vk.GetPhysicalDeviceProperties(gpu, &gpuProperties)
+	gpuProperties.Deref()
+	gpuProperties.Limits.Deref()
+	gpuProperties.SparseProperties.Deref()
+	return gpuProperties
+}
+
+func selectPhysicalDevice(gpus []vk.PhysicalDevice, selector DeviceSelector) (int, error) {
+	selected := selector(gpus)
+	if selected == nil {
+		return 0, ErrDeviceNotFound
+	}
+	for i, gpu := range gpus {
+		if gpu == selected {
+			return i, nil
+		}
+	}
+	err := fmt.Errorf("selectPhysicalDevice: selector returned a device that was not enumerated")
+	return 0, err
+}
+
+// ErrDeviceNotFound is returned when no GPU matches WithDeviceName or
+// WithVendorID, or the device selector returns nil.
+var ErrDeviceNotFound = errors.New("no matching GPU found")
+
+// findPhysicalDeviceByName returns the index of the GPU whose name contains
+// name, ignoring case. Ties are broken by the highest API version, then by
+// enumeration order.
+func findPhysicalDeviceByName(gpus []vk.PhysicalDevice, name string) (int, error) {
+	found, bestVersion := -1, uint32(0)
+	for i, gpu := range gpus {
+		gpuProperties := getDeviceProperties(gpu)
+		deviceName := vk.ToString(gpuProperties.DeviceName[:])
+		if !strings.Contains(strings.ToLower(deviceName), strings.ToLower(name)) {
+			continue
+		}
+		if found < 0 || gpuProperties.ApiVersion > bestVersion {
+			found, bestVersion = i, gpuProperties.ApiVersion
+		}
+	}
+	if found < 0 {
+		err := fmt.Errorf("%w: %q", ErrDeviceNotFound, name)
+		return 0, err
+	}
+	return found, nil
+}
+
+// findPhysicalDeviceByVendor returns the index of the GPU chosen by selector
+// among the GPUs with the given vendor ID.
+func findPhysicalDeviceByVendor(gpus []vk.PhysicalDevice, vendorID uint32, selector DeviceSelector) (int, error) {
+	var matches []vk.PhysicalDevice
+	var indices []int
+	for i, gpu := range gpus {
+		if getDeviceProperties(gpu).VendorID == vendorID {
+			matches = append(matches, gpu)
+			indices = append(indices, i)
+		}
+	}
+	if len(matches) == 0 {
+		err := fmt.Errorf("%w: vendor %s", ErrDeviceNotFound, formatVendor(vendorID))
+		return 0, err
+	}
+	if len(matches) == 1 {
+		return indices[0], nil
+	}
+	selected, err := selectPhysicalDevice(matches, selector)
+	if err != nil {
+		return 0, err
+	}
+	return indices[selected], nil
+}
+
+func checkPhysicalDeviceIndex(gpus []vk.PhysicalDevice, index int) error {
+	if index < len(gpus) {
+		return nil
+	}
+	var valid []string
+	for i, gpu := range gpus {
+		gpuProperties := getDeviceProperties(gpu)
+		valid = append(valid, fmt.Sprintf("%d (%s)", i, vk.ToString(gpuProperties.DeviceName[:])))
+	}
+	err := fmt.Errorf("GPU index %d out of range, valid indices are: %s", index, strings.Join(valid, ", "))
+	return err
+}
+
+// DeviceTypeName returns a readable name for dev, such as "Discrete GPU".
+func DeviceTypeName(dev vk.PhysicalDeviceType) string {
+	switch dev {
+	case vk.PhysicalDeviceTypeIntegratedGpu:
+		return "Integrated GPU"
+	case vk.PhysicalDeviceTypeDiscreteGpu:
+		return "Discrete GPU"
+	case vk.PhysicalDeviceTypeVirtualGpu:
+		return "Virtual GPU"
+	case vk.PhysicalDeviceTypeCpu:
+		return "CPU"
+	case vk.PhysicalDeviceTypeOther:
+		return "Other"
+	default:
+		return "Unknown"
+	}
+}
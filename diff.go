@@ -0,0 +1,189 @@
+package vulkandevice
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// ReportDifference is one difference between two reports found by
+// DiffReports. Old is empty for things that were added and New for things
+// that were removed.
+type ReportDifference struct {
+	// Device names the device the difference belongs to, such as
+	// "GPU 0 (NVIDIA GeForce RTX 3080)". It is empty for instance-level
+	// differences and for added or removed devices.
+	Device string
+	// Field is the JSON name of what changed, such as "driver_version",
+	// "limits.max_image_dimension_2d" or "memory_heaps[0].size".
+	Field string
+	Old   string
+	New   string
+}
+
+// String renders d as "+ field new", "- field old" or
+// "field: old -> new", prefixed with the device.
+func (d ReportDifference) String() string {
+	prefix := ""
+	if d.Device != "" {
+		prefix = d.Device + ": "
+	}
+	switch {
+	case d.Old == "":
+		return fmt.Sprintf("%s+ %s %s", prefix, d.Field, d.New)
+	case d.New == "":
+		return fmt.Sprintf("%s- %s %s", prefix, d.Field, d.Old)
+	default:
+		return fmt.Sprintf("%s%s: %s -> %s", prefix, d.Field, d.Old, d.New)
+	}
+}
+
+// LoadReport reads a report written by PrintJSON.
+func LoadReport(r io.Reader) (*Report, error) {
+	var report Report
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		err = fmt.Errorf("LoadReport: %s", err)
+		return nil, err
+	}
+	return &report, nil
+}
+
+// DiffReports compares two reports, such as ones saved before and after a
+// driver update, and returns the changed loader and API versions, and for
+// each device the added and removed extensions, changed limits, driver and
+// API versions and memory heap sizes. Devices are matched by device UUID
+// when both reports have it, and by name and vendor ID otherwise. An empty
+// result means the reports are equivalent.
+func DiffReports(before, after *Report) []ReportDifference {
+	var diffs []ReportDifference
+	diffs = diffString(diffs, "", "loader_version", before.LoaderVersion.Version, after.LoaderVersion.Version)
+	diffs = diffString(diffs, "", "instance_api_version", before.InstanceAPIVersion.Version, after.InstanceAPIVersion.Version)
+
+	matched := make([]bool, len(after.Devices))
+	for i := range before.Devices {
+		j := matchDevice(&before.Devices[i], after.Devices, matched)
+		if j < 0 {
+			diffs = append(diffs, ReportDifference{Field: "device", Old: deviceLabel(&before.Devices[i])})
+			continue
+		}
+		matched[j] = true
+		diffs = diffDevice(diffs, &before.Devices[i], &after.Devices[j])
+	}
+	for j := range after.Devices {
+		if !matched[j] {
+			diffs = append(diffs, ReportDifference{Field: "device", New: deviceLabel(&after.Devices[j])})
+		}
+	}
+	return diffs
+}
+
+// matchDevice returns the index of the unmatched device of devices that is
+// the same GPU as d, or -1.
+func matchDevice(d *DeviceReport, devices []DeviceReport, matched []bool) int {
+	for j := range devices {
+		if matched[j] {
+			continue
+		}
+		other := &devices[j]
+		if d.DeviceUUID != "" && other.DeviceUUID != "" {
+			if d.DeviceUUID == other.DeviceUUID {
+				return j
+			}
+			continue
+		}
+		if d.Name == other.Name && d.VendorID == other.VendorID {
+			return j
+		}
+	}
+	return -1
+}
+
+func deviceLabel(d *DeviceReport) string {
+	return fmt.Sprintf("GPU %d (%s)", d.Index, d.Name)
+}
+
+func diffDevice(diffs []ReportDifference, before, after *DeviceReport) []ReportDifference {
+	device := deviceLabel(after)
+	diffs = diffString(diffs, device, "api_version", before.APIVersion.Version, after.APIVersion.Version)
+	diffs = diffString(diffs, device, "driver_version", before.DriverVersion.Version, after.DriverVersion.Version)
+	diffs = diffString(diffs, device, "driver_name", before.DriverName, after.DriverName)
+	diffs = diffString(diffs, device, "driver_info", before.DriverInfo, after.DriverInfo)
+	diffs = diffString(diffs, device, "conformance_version", before.ConformanceVersion, after.ConformanceVersion)
+
+	// Limits are compared through their JSON encoding so every field,
+	// including the array-valued ones, is covered.
+	oldLimits, newLimits := jsonFields(before.Limits), jsonFields(after.Limits)
+	names := make([]string, 0, len(newLimits))
+	for name := range newLimits {
+		names = append(names, name)
+	}
+	for name := range oldLimits {
+		if _, ok := newLimits[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		diffs = diffString(diffs, device, "limits."+name, oldLimits[name], newLimits[name])
+	}
+
+	for i := 0; i < len(before.MemoryHeaps) || i < len(after.MemoryHeaps); i++ {
+		field := fmt.Sprintf("memory_heaps[%d].size", i)
+		switch {
+		case i >= len(after.MemoryHeaps):
+			diffs = append(diffs, ReportDifference{Device: device, Field: field, Old: fmt.Sprint(before.MemoryHeaps[i].Size)})
+		case i >= len(before.MemoryHeaps):
+			diffs = append(diffs, ReportDifference{Device: device, Field: field, New: fmt.Sprint(after.MemoryHeaps[i].Size)})
+		default:
+			diffs = diffString(diffs, device, field, fmt.Sprint(before.MemoryHeaps[i].Size), fmt.Sprint(after.MemoryHeaps[i].Size))
+		}
+	}
+
+	oldExtensions := make(map[string]uint32, len(before.Extensions))
+	for _, e := range before.Extensions {
+		oldExtensions[e.Name] = e.SpecVersion
+	}
+	newExtensions := make(map[string]uint32, len(after.Extensions))
+	for _, e := range after.Extensions {
+		newExtensions[e.Name] = e.SpecVersion
+		specVersion, ok := oldExtensions[e.Name]
+		if !ok {
+			diffs = append(diffs, ReportDifference{Device: device, Field: "extensions", New: e.Name})
+			continue
+		}
+		diffs = diffString(diffs, device, "extensions."+e.Name+".spec_version", fmt.Sprint(specVersion), fmt.Sprint(e.SpecVersion))
+	}
+	for _, e := range before.Extensions {
+		if _, ok := newExtensions[e.Name]; !ok {
+			diffs = append(diffs, ReportDifference{Device: device, Field: "extensions", Old: e.Name})
+		}
+	}
+	return diffs
+}
+
+// diffString appends a difference to diffs if before and after differ.
+func diffString(diffs []ReportDifference, device, field, before, after string) []ReportDifference {
+	if before == after {
+		return diffs
+	}
+	return append(diffs, ReportDifference{Device: device, Field: field, Old: before, New: after})
+}
+
+// jsonFields returns the JSON encoding of each field of the struct value,
+// keyed by JSON name.
+func jsonFields(value interface{}) map[string]string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil
+	}
+	fields := make(map[string]string, len(raw))
+	for name, value := range raw {
+		fields[name] = string(value)
+	}
+	return fields
+}
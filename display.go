@@ -0,0 +1,221 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// displayExtensionName lets GPUs present directly to displays, without a
+// window system.
+const displayExtensionName = "VK_KHR_display"
+
+// WithDisplays enables VK_KHR_display on the instance when the loader
+// offers it, for GetDisplays. It is opt-in because probing displays can be
+// slow on some drivers.
+func WithDisplays() Option {
+	return func(c *deviceConfig) {
+		c.displays = true
+	}
+}
+
+// DisplayModeInfo is a display mode: a resolution and a refresh rate.
+type DisplayModeInfo struct {
+	Width  uint32 `json:"width"`
+	Height uint32 `json:"height"`
+	// RefreshRate is in Hz; Vulkan reports it in mHz.
+	RefreshRate float64 `json:"refresh_rate"`
+}
+
+// DisplayInfo describes a display attached to a GPU and its modes.
+type DisplayInfo struct {
+	Name string `json:"name"`
+	// PhysicalWidthMM and PhysicalHeightMM are the size of the visible
+	// area in millimeters.
+	PhysicalWidthMM  uint32 `json:"physical_width_mm"`
+	PhysicalHeightMM uint32 `json:"physical_height_mm"`
+	// PhysicalWidth and PhysicalHeight are the native resolution.
+	PhysicalWidth     uint32            `json:"physical_width"`
+	PhysicalHeight    uint32            `json:"physical_height"`
+	PersistentContent bool              `json:"persistent_content"`
+	Modes             []DisplayModeInfo `json:"modes"`
+}
+
+// DisplayPlaneInfo describes a display plane of a GPU.
+type DisplayPlaneInfo struct {
+	Index             uint32 `json:"index"`
+	CurrentStackIndex uint32 `json:"current_stack_index"`
+	// SupportedDisplays are the indexes, in the displays returned along
+	// with the plane, of the displays the plane can be used with.
+	SupportedDisplays []int `json:"supported_displays"`
+}
+
+// GetDisplays enumerates the displays attached to the GPU at gpuIndex with
+// their modes, and its display planes. The instance must have been created
+// with WithDisplays and the loader must support VK_KHR_display, otherwise it
+// fails with ErrExtensionNotSupported.
+func GetDisplays(v *VulkanDeviceInfo, gpuIndex int) ([]DisplayInfo, []DisplayPlaneInfo, error) {
+	if !v.instanceExtensionEnabled(displayExtensionName) {
+		err := fmt.Errorf("%w: %s", ErrExtensionNotSupported, displayExtensionName)
+		if hasExtension(v.instanceExtensions, displayExtensionName) {
+			err = fmt.Errorf("%s not enabled, see WithDisplays", displayExtensionName)
+		}
+		return nil, nil, err
+	}
+	gpu := v.gpuDevices[gpuIndex]
+
+	var displayCount uint32
+	if err := vk.Error(vk.GetPhysicalDeviceDisplayProperties(gpu, &displayCount, nil)); err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceDisplayPropertiesKHR failed with %s", err)
+		return nil, nil, err
+	}
+	properties := make([]vk.DisplayProperties, displayCount)
+	if displayCount > 0 {
+		if err := vk.Error(vk.GetPhysicalDeviceDisplayProperties(gpu, &displayCount, properties)); err != nil {
+			err = fmt.Errorf("vkGetPhysicalDeviceDisplayPropertiesKHR failed with %s", err)
+			return nil, nil, err
+		}
+	}
+	displays := make([]DisplayInfo, 0, displayCount)
+	for i := range properties[:displayCount] {
+		p := &properties[i]
+		p.Deref()
+		p.PhysicalDimensions.Deref()
+		p.PhysicalResolution.Deref()
+		modes, err := getDisplayModes(gpu, p.Display)
+		if err != nil {
+			return nil, nil, err
+		}
+		displays = append(displays, DisplayInfo{
+			Name:              p.DisplayName,
+			PhysicalWidthMM:   p.PhysicalDimensions.Width,
+			PhysicalHeightMM:  p.PhysicalDimensions.Height,
+			PhysicalWidth:     p.PhysicalResolution.Width,
+			PhysicalHeight:    p.PhysicalResolution.Height,
+			PersistentContent: p.PersistentContent.B(),
+			Modes:             modes,
+		})
+	}
+
+	var planeCount uint32
+	if err := vk.Error(vk.GetPhysicalDeviceDisplayPlaneProperties(gpu, &planeCount, nil)); err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceDisplayPlanePropertiesKHR failed with %s", err)
+		return nil, nil, err
+	}
+	planeProperties := make([]vk.DisplayPlaneProperties, planeCount)
+	if planeCount > 0 {
+		if err := vk.Error(vk.GetPhysicalDeviceDisplayPlaneProperties(gpu, &planeCount, planeProperties)); err != nil {
+			err = fmt.Errorf("vkGetPhysicalDeviceDisplayPlanePropertiesKHR failed with %s", err)
+			return nil, nil, err
+		}
+	}
+	planes := make([]DisplayPlaneInfo, 0, planeCount)
+	for i := range planeProperties[:planeCount] {
+		planeProperties[i].Deref()
+		plane := DisplayPlaneInfo{Index: uint32(i), CurrentStackIndex: planeProperties[i].CurrentStackIndex}
+		supported, err := getDisplayPlaneSupportedDisplays(gpu, uint32(i))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, display := range supported {
+			for j := range properties[:displayCount] {
+				if properties[j].Display == display {
+					plane.SupportedDisplays = append(plane.SupportedDisplays, j)
+				}
+			}
+		}
+		planes = append(planes, plane)
+	}
+	return displays, planes, nil
+}
+
+func getDisplayModes(gpu vk.PhysicalDevice, display vk.Display) ([]DisplayModeInfo, error) {
+	var modeCount uint32
+	if err := vk.Error(vk.GetDisplayModeProperties(gpu, display, &modeCount, nil)); err != nil {
+		err = fmt.Errorf("vkGetDisplayModePropertiesKHR failed with %s", err)
+		return nil, err
+	}
+	if modeCount == 0 {
+		return nil, nil
+	}
+	properties := make([]vk.DisplayModeProperties, modeCount)
+	if err := vk.Error(vk.GetDisplayModeProperties(gpu, display, &modeCount, properties)); err != nil {
+		err = fmt.Errorf("vkGetDisplayModePropertiesKHR failed with %s", err)
+		return nil, err
+	}
+	modes := make([]DisplayModeInfo, 0, modeCount)
+	for i := range properties[:modeCount] {
+		properties[i].Deref()
+		parameters := properties[i].Parameters
+		parameters.Deref()
+		parameters.VisibleRegion.Deref()
+		modes = append(modes, DisplayModeInfo{
+			Width:       parameters.VisibleRegion.Width,
+			Height:      parameters.VisibleRegion.Height,
+			RefreshRate: float64(parameters.RefreshRate) / 1000,
+		})
+	}
+	return modes, nil
+}
+
+func getDisplayPlaneSupportedDisplays(gpu vk.PhysicalDevice, planeIndex uint32) ([]vk.Display, error) {
+	var displayCount uint32
+	if err := vk.Error(vk.GetDisplayPlaneSupportedDisplays(gpu, planeIndex, &displayCount, nil)); err != nil {
+		err = fmt.Errorf("vkGetDisplayPlaneSupportedDisplaysKHR failed with %s", err)
+		return nil, err
+	}
+	if displayCount == 0 {
+		return nil, nil
+	}
+	displays := make([]vk.Display, displayCount)
+	if err := vk.Error(vk.GetDisplayPlaneSupportedDisplays(gpu, planeIndex, &displayCount, displays)); err != nil {
+		err = fmt.Errorf("vkGetDisplayPlaneSupportedDisplaysKHR failed with %s", err)
+		return nil, err
+	}
+	return displays[:displayCount], nil
+}
+
+// PrintDisplays prints the displays attached to the GPU at gpuIndex with
+// their modes, and which displays each display plane supports.
+func PrintDisplays(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Displays", gpuIndex))
+	displays, planes, err := GetDisplays(v, gpuIndex)
+	if err != nil {
+		table.AddRow("Displays", err)
+		fmt.Fprintln(w, "\n"+table.Render())
+		return
+	}
+	if len(displays) == 0 {
+		table.AddRow("Displays", "None")
+	}
+	for i, d := range displays {
+		addSection(table, fmt.Sprintf("Display %d", i))
+		table.AddRow("Name", d.Name)
+		table.AddRow("Physical Size", fmt.Sprintf("%dx%d mm", d.PhysicalWidthMM, d.PhysicalHeightMM))
+		table.AddRow("Physical Resolution", fmt.Sprintf("%dx%d", d.PhysicalWidth, d.PhysicalHeight))
+		table.AddRow("Persistent Content", checkMark(d.PersistentContent))
+		for _, mode := range d.Modes {
+			table.AddRow("Mode", fmt.Sprintf("%dx%d @ %.2f Hz", mode.Width, mode.Height, mode.RefreshRate))
+		}
+	}
+	if len(planes) > 0 {
+		addSection(table, "Planes")
+	}
+	for _, p := range planes {
+		supported := make([]string, len(p.SupportedDisplays))
+		for i, index := range p.SupportedDisplays {
+			supported[i] = fmt.Sprintf("Display %d", index)
+		}
+		if len(supported) == 0 {
+			supported = append(supported, "None")
+		}
+		table.AddRow(fmt.Sprintf("Plane %d (stack index %d)", p.Index, p.CurrentStackIndex), strings.Join(supported, ", "))
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
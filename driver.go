@@ -0,0 +1,134 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"runtime"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// structureTypePhysicalDeviceDriverProperties is the structure type of
+// VkPhysicalDeviceDriverProperties.
+const structureTypePhysicalDeviceDriverProperties vk.StructureType = 1000196000
+
+// physicalDeviceDriverProperties has the C layout of
+// VkPhysicalDeviceDriverProperties.
+type physicalDeviceDriverProperties struct {
+	chainHeader
+	DriverID           int32
+	DriverName         [vk.MaxDriverNameSize]byte
+	DriverInfo         [vk.MaxDriverInfoSize]byte
+	ConformanceVersion [4]uint8
+}
+
+// driverIDNames maps VkDriverId values to their enum names.
+var driverIDNames = map[int32]string{
+	1:  "VK_DRIVER_ID_AMD_PROPRIETARY",
+	2:  "VK_DRIVER_ID_AMD_OPEN_SOURCE",
+	3:  "VK_DRIVER_ID_MESA_RADV",
+	4:  "VK_DRIVER_ID_NVIDIA_PROPRIETARY",
+	5:  "VK_DRIVER_ID_INTEL_PROPRIETARY_WINDOWS",
+	6:  "VK_DRIVER_ID_INTEL_OPEN_SOURCE_MESA",
+	7:  "VK_DRIVER_ID_IMAGINATION_PROPRIETARY",
+	8:  "VK_DRIVER_ID_QUALCOMM_PROPRIETARY",
+	9:  "VK_DRIVER_ID_ARM_PROPRIETARY",
+	10: "VK_DRIVER_ID_GOOGLE_SWIFTSHADER",
+	11: "VK_DRIVER_ID_GGP_PROPRIETARY",
+	12: "VK_DRIVER_ID_BROADCOM_PROPRIETARY",
+	13: "VK_DRIVER_ID_MESA_LLVMPIPE",
+	14: "VK_DRIVER_ID_MOLTENVK",
+	15: "VK_DRIVER_ID_COREAVI_PROPRIETARY",
+	16: "VK_DRIVER_ID_JUICE_PROPRIETARY",
+	17: "VK_DRIVER_ID_VERISILICON_PROPRIETARY",
+	18: "VK_DRIVER_ID_MESA_TURNIP",
+	19: "VK_DRIVER_ID_MESA_V3DV",
+	20: "VK_DRIVER_ID_MESA_PANVK",
+	21: "VK_DRIVER_ID_SAMSUNG_PROPRIETARY",
+	22: "VK_DRIVER_ID_MESA_VENUS",
+	23: "VK_DRIVER_ID_MESA_DOZEN",
+	24: "VK_DRIVER_ID_MESA_NVK",
+	25: "VK_DRIVER_ID_IMAGINATION_OPEN_SOURCE_MESA",
+	26: "VK_DRIVER_ID_MESA_HONEYKRISP",
+}
+
+func driverIDName(id int32) string {
+	if name, ok := driverIDNames[id]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown (%d)", id)
+}
+
+// getDriverProperties queries VkPhysicalDeviceDriverProperties for the GPU
+// at gpuIndex. ok is false if the GPU supports neither Vulkan 1.2 nor
+// VK_KHR_driver_properties, or properties2 queries are unavailable.
+func getDriverProperties(v *VulkanDeviceInfo, gpuIndex int) (driver physicalDeviceDriverProperties, ok bool) {
+	gpu := v.gpuDevices[gpuIndex]
+	if getDeviceProperties(gpu).ApiVersion < vk.MakeVersion(1, 2, 0) {
+		extensions, err := EnumerateDeviceExtensions(gpu)
+		if err != nil || !hasExtension(extensions, "VK_KHR_driver_properties") {
+			return driver, false
+		}
+	}
+
+	driver.SType = structureTypePhysicalDeviceDriverProperties
+	var chain structChain
+	chain.add(unsafe.Pointer(&driver), unsafe.Sizeof(driver))
+	return driver, queryProperties2(v, gpu, &chain)
+}
+
+// DriverProperties identifies the driver of a GPU in a vendor-neutral way,
+// unlike the vendor-encoded driverVersion.
+type DriverProperties struct {
+	// DriverID is the VkDriverId and DriverIDName its enum name, such as
+	// "VK_DRIVER_ID_MESA_RADV".
+	DriverID     int32
+	DriverIDName string
+	// DriverName and DriverInfo are the driver's own name and version
+	// string, such as "radv" and "Mesa 23.1.0".
+	DriverName string
+	DriverInfo string
+	// ConformanceVersion is the Vulkan CTS version the driver passed, as
+	// major.minor.subminor.patch.
+	ConformanceVersion string
+}
+
+// GetDriverProperties returns the driver properties of the GPU at gpuIndex.
+// It fails with ErrExtensionNotSupported if the GPU supports neither Vulkan
+// 1.2 nor VK_KHR_driver_properties, or the instance can't make properties2
+// queries. Like GetPhysicalDeviceProperties2, it takes a GPU index because
+// the query goes through v's instance.
+func GetDriverProperties(v *VulkanDeviceInfo, gpuIndex int) (DriverProperties, error) {
+	driver, ok := getDriverProperties(v, gpuIndex)
+	if !ok {
+		err := fmt.Errorf("%w: VK_KHR_driver_properties on GPU %d", ErrExtensionNotSupported, gpuIndex)
+		return DriverProperties{}, err
+	}
+	return DriverProperties{
+		DriverID:           driver.DriverID,
+		DriverIDName:       driverIDName(driver.DriverID),
+		DriverName:         vk.ToString(driver.DriverName[:]),
+		DriverInfo:         vk.ToString(driver.DriverInfo[:]),
+		ConformanceVersion: formatConformanceVersion(driver.ConformanceVersion),
+	}, nil
+}
+
+// formatConformanceVersion renders a VkConformanceVersion as
+// major.minor.subminor.patch.
+func formatConformanceVersion(version [4]uint8) string {
+	return fmt.Sprintf("%d.%d.%d.%d", version[0], version[1], version[2], version[3])
+}
+
+// formatDriverVersion decodes driverVersion using the vendor's encoding.
+// NVIDIA uses 10.8.8.6 bits and Intel's Windows driver 18.14 bits; other
+// drivers follow the Vulkan version encoding.
+func formatDriverVersion(vendorID, driverVersion uint32) string {
+	switch {
+	case vendorID == VendorIDNVIDIA:
+		return fmt.Sprintf("%d.%d.%d.%d", driverVersion>>22&0x3ff, driverVersion>>14&0xff,
+			driverVersion>>6&0xff, driverVersion&0x3f)
+	case vendorID == VendorIDIntel && runtime.GOOS == "windows":
+		return fmt.Sprintf("%d.%d", driverVersion>>14, driverVersion&0x3fff)
+	}
+	return vk.Version(driverVersion).String()
+}
@@ -0,0 +1,128 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// PrintExtensions prints the device extensions supported by the GPU at
+// gpuIndex, sorted by name, followed by which of the device extensions
+// requested from NewVulkanDevice the GPU supports.
+func PrintExtensions(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Device Extensions", gpuIndex))
+	extensions, err := EnumerateDeviceExtensions(v.gpuDevices[gpuIndex])
+	if err != nil {
+		table.AddRow("Error", err.Error())
+		fmt.Fprintln(w, "\n"+table.Render())
+		return
+	}
+	table.AddHeaders("Extension", "Spec Version")
+	for _, extension := range sortExtensions(extensions) {
+		table.AddRow(vk.ToString(extension.ExtensionName[:]), extension.SpecVersion)
+	}
+
+	requested := tablewriter.CreateTable()
+	requested.UTF8Box()
+	requested.AddTitle(fmt.Sprintf("GPU %d Requested Extensions", gpuIndex))
+	requested.AddHeaders("Extension", "Status")
+	for _, name := range v.requestedExtensions {
+		if hasExtension(extensions, name) {
+			requested.AddRow(name, "✓ found")
+		} else {
+			requested.AddRow(name, "✗ missing")
+		}
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+	if len(v.requestedExtensions) > 0 {
+		fmt.Fprintln(w, "\n"+requested.Render())
+	}
+}
+
+// EnumerateDeviceExtensions returns the extensions supported by gpu.
+func EnumerateDeviceExtensions(gpu vk.PhysicalDevice) ([]vk.ExtensionProperties, error) {
+	var extensionCount uint32
+	err := vk.Error(vk.EnumerateDeviceExtensionProperties(gpu, "", &extensionCount, nil))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateDeviceExtensionProperties failed with %s", err)
+		return nil, err
+	}
+	extensions := make([]vk.ExtensionProperties, extensionCount)
+	err = vk.Error(vk.EnumerateDeviceExtensionProperties(gpu, "", &extensionCount, extensions))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateDeviceExtensionProperties failed with %s", err)
+		return nil, err
+	}
+	for i := range extensions {
+		extensions[i].Deref()
+	}
+	return extensions, nil
+}
+
+// ErrExtensionNotSupported is returned when a requested instance or device
+// extension isn't available. The error lists the missing extensions.
+var ErrExtensionNotSupported = errors.New("extension not supported")
+
+// appendNulTerminated appends names to list, NUL terminating the ones that
+// aren't already.
+func appendNulTerminated(list []string, names []string) []string {
+	for _, name := range names {
+		if !strings.HasSuffix(name, "\x00") {
+			name += "\x00"
+		}
+		list = append(list, name)
+	}
+	return list
+}
+
+// missingExtensions returns the names, without NUL terminators, that
+// aren't among extensions.
+func missingExtensions(extensions []vk.ExtensionProperties, names []string) []string {
+	var missing []string
+	for _, name := range names {
+		if !hasExtension(extensions, name) {
+			missing = append(missing, vk.ToString([]byte(name)))
+		}
+	}
+	return missing
+}
+
+// containsExtension reports whether names, which may be NUL terminated,
+// includes name.
+func containsExtension(names []string, name string) bool {
+	for _, n := range names {
+		if vk.ToString([]byte(n)) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasExtension reports whether name, with or without a NUL terminator, is
+// among extensions.
+func hasExtension(extensions []vk.ExtensionProperties, name string) bool {
+	name = vk.ToString([]byte(name))
+	for _, extension := range extensions {
+		if vk.ToString(extension.ExtensionName[:]) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// sortExtensions returns a copy of extensions sorted by name.
+func sortExtensions(extensions []vk.ExtensionProperties) []vk.ExtensionProperties {
+	sorted := append([]vk.ExtensionProperties{}, extensions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return vk.ToString(sorted[i].ExtensionName[:]) < vk.ToString(sorted[j].ExtensionName[:])
+	})
+	return sorted
+}
@@ -0,0 +1,204 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// physicalDeviceExternalBufferInfo has the C layout of
+// VkPhysicalDeviceExternalBufferInfo.
+type physicalDeviceExternalBufferInfo struct {
+	chainHeader
+	Flags      vk.BufferCreateFlags
+	Usage      vk.BufferUsageFlags
+	HandleType vk.ExternalMemoryHandleTypeFlagBits
+}
+
+// externalBufferProperties has the C layout of VkExternalBufferProperties.
+type externalBufferProperties struct {
+	chainHeader
+	ExternalMemoryFeatures        vk.ExternalMemoryFeatureFlags
+	ExportFromImportedHandleTypes vk.ExternalMemoryHandleTypeFlags
+	CompatibleHandleTypes         vk.ExternalMemoryHandleTypeFlags
+}
+
+// physicalDeviceExternalHandleInfo has the C layout of
+// VkPhysicalDeviceExternalSemaphoreInfo and
+// VkPhysicalDeviceExternalFenceInfo.
+type physicalDeviceExternalHandleInfo struct {
+	chainHeader
+	HandleType uint32
+}
+
+// externalHandleProperties has the C layout of
+// VkExternalSemaphoreProperties and VkExternalFenceProperties, whose
+// feature bits have the same values.
+type externalHandleProperties struct {
+	chainHeader
+	ExportFromImportedHandleTypes uint32
+	CompatibleHandleTypes         uint32
+	Features                      uint32
+}
+
+// externalBufferUsage is the usage the external buffer support is queried
+// for: a storage buffer shared with another API, such as CUDA, and copied
+// to and from.
+const externalBufferUsage = vk.BufferUsageFlags(vk.BufferUsageStorageBufferBit |
+	vk.BufferUsageTransferSrcBit | vk.BufferUsageTransferDstBit)
+
+// externalMemoryHandleTypes are the memory handle types reported by
+// GetExternalInterop.
+var externalMemoryHandleTypes = []flagName{
+	{uint32(vk.ExternalMemoryHandleTypeOpaqueFdBit), "OPAQUE_FD"},
+	{uint32(vk.ExternalMemoryHandleTypeOpaqueWin32Bit), "OPAQUE_WIN32"},
+	{uint32(vk.ExternalMemoryHandleTypeOpaqueWin32KmtBit), "OPAQUE_WIN32_KMT"},
+	{uint32(vk.ExternalMemoryHandleTypeDmaBufBit), "DMA_BUF"},
+	{uint32(vk.ExternalMemoryHandleTypeD3d12HeapBit), "D3D12_HEAP"},
+	{uint32(vk.ExternalMemoryHandleTypeD3d12ResourceBit), "D3D12_RESOURCE"},
+}
+
+// externalSemaphoreHandleTypes are the semaphore handle types reported by
+// GetExternalInterop.
+var externalSemaphoreHandleTypes = []flagName{
+	{uint32(vk.ExternalSemaphoreHandleTypeOpaqueFdBit), "OPAQUE_FD"},
+	{uint32(vk.ExternalSemaphoreHandleTypeOpaqueWin32Bit), "OPAQUE_WIN32"},
+	{uint32(vk.ExternalSemaphoreHandleTypeD3d12FenceBit), "D3D12_FENCE"},
+	{uint32(vk.ExternalSemaphoreHandleTypeSyncFdBit), "SYNC_FD"},
+}
+
+// externalFenceHandleTypes are the fence handle types reported by
+// GetExternalInterop.
+var externalFenceHandleTypes = []flagName{
+	{uint32(vk.ExternalFenceHandleTypeOpaqueFdBit), "OPAQUE_FD"},
+	{uint32(vk.ExternalFenceHandleTypeOpaqueWin32Bit), "OPAQUE_WIN32"},
+	{uint32(vk.ExternalFenceHandleTypeSyncFdBit), "SYNC_FD"},
+}
+
+// ExternalHandleSupport is whether objects of one kind can be shared with
+// other APIs or processes through one handle type.
+type ExternalHandleSupport struct {
+	// Object is "buffer", "semaphore" or "fence".
+	Object string `json:"object"`
+	// HandleType is the handle type without the VK_EXTERNAL_*_HANDLE_TYPE_
+	// prefix and _BIT suffix, such as "OPAQUE_FD".
+	HandleType string `json:"handle_type"`
+	Exportable bool   `json:"exportable"`
+	Importable bool   `json:"importable"`
+	// DedicatedOnly is whether external buffer memory must be a dedicated
+	// allocation. It is always false for semaphores and fences.
+	DedicatedOnly bool `json:"dedicated_only"`
+}
+
+// GetExternalInterop queries which common external handle types buffers,
+// semaphores and fences of the GPU at gpuIndex can be exported to and
+// imported from, for interop with CUDA, Direct3D 12 or other processes.
+// Buffers are queried for storage and transfer usage. The queries are core
+// in Vulkan 1.1, so the instance and the GPU must support it, otherwise it
+// fails with ErrRequiresVulkan11.
+func GetExternalInterop(v *VulkanDeviceInfo, gpuIndex int) ([]ExternalHandleSupport, error) {
+	gpu := v.gpuDevices[gpuIndex]
+	if v.apiVersion < vk.MakeVersion(1, 1, 0) || getDeviceProperties(gpu).ApiVersion < vk.MakeVersion(1, 1, 0) {
+		err := fmt.Errorf("%w: external memory, semaphore and fence capabilities", ErrRequiresVulkan11)
+		return nil, err
+	}
+
+	var support []ExternalHandleSupport
+	for _, handleType := range externalMemoryHandleTypes {
+		info := physicalDeviceExternalBufferInfo{
+			chainHeader: chainHeader{SType: vk.StructureTypePhysicalDeviceExternalBufferInfo},
+			Usage:       externalBufferUsage,
+			HandleType:  vk.ExternalMemoryHandleTypeFlagBits(handleType.bit),
+		}
+		properties := externalBufferProperties{chainHeader: chainHeader{SType: vk.StructureTypeExternalBufferProperties}}
+		if !queryExternalProperties(v, gpu, "vkGetPhysicalDeviceExternalBufferProperties",
+			unsafe.Pointer(&info), unsafe.Sizeof(info), unsafe.Pointer(&properties), unsafe.Sizeof(properties)) {
+			return nil, ErrRequiresVulkan11
+		}
+		features := properties.ExternalMemoryFeatures
+		support = append(support, ExternalHandleSupport{
+			Object:        "buffer",
+			HandleType:    handleType.name,
+			Exportable:    features&vk.ExternalMemoryFeatureFlags(vk.ExternalMemoryFeatureExportableBit) != 0,
+			Importable:    features&vk.ExternalMemoryFeatureFlags(vk.ExternalMemoryFeatureImportableBit) != 0,
+			DedicatedOnly: features&vk.ExternalMemoryFeatureFlags(vk.ExternalMemoryFeatureDedicatedOnlyBit) != 0,
+		})
+	}
+	handleQueries := []struct {
+		object      string
+		name        string
+		handleTypes []flagName
+		infoType    vk.StructureType
+		propsType   vk.StructureType
+	}{
+		{"semaphore", "vkGetPhysicalDeviceExternalSemaphoreProperties", externalSemaphoreHandleTypes,
+			vk.StructureTypePhysicalDeviceExternalSemaphoreInfo, vk.StructureTypeExternalSemaphoreProperties},
+		{"fence", "vkGetPhysicalDeviceExternalFenceProperties", externalFenceHandleTypes,
+			vk.StructureTypePhysicalDeviceExternalFenceInfo, vk.StructureTypeExternalFenceProperties},
+	}
+	for _, q := range handleQueries {
+		for _, handleType := range q.handleTypes {
+			info := physicalDeviceExternalHandleInfo{chainHeader: chainHeader{SType: q.infoType}, HandleType: handleType.bit}
+			properties := externalHandleProperties{chainHeader: chainHeader{SType: q.propsType}}
+			if !queryExternalProperties(v, gpu, q.name,
+				unsafe.Pointer(&info), unsafe.Sizeof(info), unsafe.Pointer(&properties), unsafe.Sizeof(properties)) {
+				return nil, ErrRequiresVulkan11
+			}
+			support = append(support, ExternalHandleSupport{
+				Object:     q.object,
+				HandleType: handleType.name,
+				// VK_EXTERNAL_SEMAPHORE_FEATURE_* and VK_EXTERNAL_FENCE_FEATURE_*
+				// have the same values.
+				Exportable: properties.Features&uint32(vk.ExternalSemaphoreFeatureExportableBit) != 0,
+				Importable: properties.Features&uint32(vk.ExternalSemaphoreFeatureImportableBit) != 0,
+			})
+		}
+	}
+	return support, nil
+}
+
+// queryExternalProperties calls the external properties query name with
+// the structure at info, filling in the one at out. It returns false if
+// the instance doesn't provide the entry point.
+func queryExternalProperties(v *VulkanDeviceInfo, gpu vk.PhysicalDevice, name string,
+	info unsafe.Pointer, infoSize uintptr, out unsafe.Pointer, outSize uintptr) bool {
+
+	var in, result structChain
+	in.add(info, infoSize)
+	result.add(out, outSize)
+	defer in.free()
+	defer result.free()
+	if !physicalDeviceInfoQuery(v.instance, name, gpu, in.link(), result.link()) {
+		return false
+	}
+	result.read()
+	return true
+}
+
+// PrintExternalInterop prints, for the common external handle types of
+// buffers, semaphores and fences of the GPU at gpuIndex, whether they can
+// be exported and imported and whether buffer memory must be dedicated.
+func PrintExternalInterop(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d External Interop", gpuIndex))
+	support, err := GetExternalInterop(v, gpuIndex)
+	if err != nil {
+		table.AddRow("External Interop", err)
+		fmt.Fprintln(w, "\n"+table.Render())
+		return
+	}
+	table.AddHeaders("Object", "Handle Type", "Export", "Import", "Dedicated Only")
+	for _, s := range support {
+		dedicated := ""
+		if s.Object == "buffer" {
+			dedicated = checkMark(s.DedicatedOnly)
+		}
+		table.AddRow(s.Object, s.HandleType, checkMark(s.Exportable), checkMark(s.Importable), dedicated)
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
@@ -0,0 +1,119 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// deviceFeature maps a Vulkan 1.0 feature name, as spelled in
+// VkPhysicalDeviceFeatures, to its field.
+type deviceFeature struct {
+	name  string
+	value func(f *vk.PhysicalDeviceFeatures) vk.Bool32
+}
+
+// deviceFeatures lists every VkPhysicalDeviceFeatures field in spec order.
+var deviceFeatures = []deviceFeature{
+	{"robustBufferAccess", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.RobustBufferAccess }},
+	{"fullDrawIndexUint32", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.FullDrawIndexUint32 }},
+	{"imageCubeArray", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ImageCubeArray }},
+	{"independentBlend", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.IndependentBlend }},
+	{"geometryShader", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.GeometryShader }},
+	{"tessellationShader", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.TessellationShader }},
+	{"sampleRateShading", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.SampleRateShading }},
+	{"dualSrcBlend", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.DualSrcBlend }},
+	{"logicOp", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.LogicOp }},
+	{"multiDrawIndirect", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.MultiDrawIndirect }},
+	{"drawIndirectFirstInstance", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.DrawIndirectFirstInstance }},
+	{"depthClamp", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.DepthClamp }},
+	{"depthBiasClamp", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.DepthBiasClamp }},
+	{"fillModeNonSolid", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.FillModeNonSolid }},
+	{"depthBounds", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.DepthBounds }},
+	{"wideLines", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.WideLines }},
+	{"largePoints", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.LargePoints }},
+	{"alphaToOne", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.AlphaToOne }},
+	{"multiViewport", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.MultiViewport }},
+	{"samplerAnisotropy", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.SamplerAnisotropy }},
+	{"textureCompressionETC2", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.TextureCompressionETC2 }},
+	{"textureCompressionASTC_LDR", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.TextureCompressionASTC_LDR }},
+	{"textureCompressionBC", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.TextureCompressionBC }},
+	{"occlusionQueryPrecise", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.OcclusionQueryPrecise }},
+	{"pipelineStatisticsQuery", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.PipelineStatisticsQuery }},
+	{"vertexPipelineStoresAndAtomics", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.VertexPipelineStoresAndAtomics }},
+	{"fragmentStoresAndAtomics", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.FragmentStoresAndAtomics }},
+	{"shaderTessellationAndGeometryPointSize", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderTessellationAndGeometryPointSize }},
+	{"shaderImageGatherExtended", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderImageGatherExtended }},
+	{"shaderStorageImageExtendedFormats", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderStorageImageExtendedFormats }},
+	{"shaderStorageImageMultisample", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderStorageImageMultisample }},
+	{"shaderStorageImageReadWithoutFormat", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderStorageImageReadWithoutFormat }},
+	{"shaderStorageImageWriteWithoutFormat", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderStorageImageWriteWithoutFormat }},
+	{"shaderUniformBufferArrayDynamicIndexing", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderUniformBufferArrayDynamicIndexing }},
+	{"shaderSampledImageArrayDynamicIndexing", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderSampledImageArrayDynamicIndexing }},
+	{"shaderStorageBufferArrayDynamicIndexing", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderStorageBufferArrayDynamicIndexing }},
+	{"shaderStorageImageArrayDynamicIndexing", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderStorageImageArrayDynamicIndexing }},
+	{"shaderClipDistance", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderClipDistance }},
+	{"shaderCullDistance", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderCullDistance }},
+	{"shaderFloat64", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderFloat64 }},
+	{"shaderInt64", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderInt64 }},
+	{"shaderInt16", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderInt16 }},
+	{"shaderResourceResidency", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderResourceResidency }},
+	{"shaderResourceMinLod", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.ShaderResourceMinLod }},
+	{"sparseBinding", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.SparseBinding }},
+	{"sparseResidencyBuffer", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.SparseResidencyBuffer }},
+	{"sparseResidencyImage2D", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.SparseResidencyImage2D }},
+	{"sparseResidencyImage3D", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.SparseResidencyImage3D }},
+	{"sparseResidency2Samples", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.SparseResidency2Samples }},
+	{"sparseResidency4Samples", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.SparseResidency4Samples }},
+	{"sparseResidency8Samples", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.SparseResidency8Samples }},
+	{"sparseResidency16Samples", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.SparseResidency16Samples }},
+	{"sparseResidencyAliased", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.SparseResidencyAliased }},
+	{"variableMultisampleRate", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.VariableMultisampleRate }},
+	{"inheritedQueries", func(f *vk.PhysicalDeviceFeatures) vk.Bool32 { return f.InheritedQueries }},
+}
+
+// GetDeviceFeatures returns the Vulkan 1.0 features supported by gpu.
+func GetDeviceFeatures(gpu vk.PhysicalDevice) vk.PhysicalDeviceFeatures {
+	var features vk.PhysicalDeviceFeatures
+	vk.GetPhysicalDeviceFeatures(gpu, &features)
+	features.Deref()
+	return features
+}
+
+// SupportsFeature reports whether gpu supports feature, named as in
+// VkPhysicalDeviceFeatures (e.g. "geometryShader"). Names are matched
+// case-insensitively; unknown names are unsupported.
+func SupportsFeature(gpu vk.PhysicalDevice, feature string) bool {
+	features := GetDeviceFeatures(gpu)
+	for _, f := range deviceFeatures {
+		if strings.EqualFold(f.name, feature) {
+			return f.value(&features).B()
+		}
+	}
+	return false
+}
+
+// PrintDeviceFeatures prints whether the GPU at gpuIndex supports each
+// Vulkan 1.0 feature.
+func PrintDeviceFeatures(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	features := GetDeviceFeatures(v.gpuDevices[gpuIndex])
+
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Device Features", gpuIndex))
+	for _, f := range deviceFeatures {
+		table.AddRow(f.name, checkMark(f.value(&features).B()))
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
+
+func checkMark(supported bool) string {
+	if supported {
+		return "✓"
+	}
+	return "✗"
+}
@@ -0,0 +1,232 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// Structure types of the Vulkan 1.2 and 1.3 feature structures, which the
+// vulkan-go bindings predate.
+const (
+	structureTypePhysicalDeviceVulkan11Features vk.StructureType = 49
+	structureTypePhysicalDeviceVulkan12Features vk.StructureType = 51
+	structureTypePhysicalDeviceVulkan13Features vk.StructureType = 53
+)
+
+// vulkan11FeatureNames lists the VkPhysicalDeviceVulkan11Features fields in
+// declaration order.
+var vulkan11FeatureNames = []string{
+	"storageBuffer16BitAccess",
+	"uniformAndStorageBuffer16BitAccess",
+	"storagePushConstant16",
+	"storageInputOutput16",
+	"multiview",
+	"multiviewGeometryShader",
+	"multiviewTessellationShader",
+	"variablePointersStorageBuffer",
+	"variablePointers",
+	"protectedMemory",
+	"samplerYcbcrConversion",
+	"shaderDrawParameters",
+}
+
+// vulkan12FeatureNames lists the VkPhysicalDeviceVulkan12Features fields in
+// declaration order.
+var vulkan12FeatureNames = []string{
+	"samplerMirrorClampToEdge",
+	"drawIndirectCount",
+	"storageBuffer8BitAccess",
+	"uniformAndStorageBuffer8BitAccess",
+	"storagePushConstant8",
+	"shaderBufferInt64Atomics",
+	"shaderSharedInt64Atomics",
+	"shaderFloat16",
+	"shaderInt8",
+	"descriptorIndexing",
+	"shaderInputAttachmentArrayDynamicIndexing",
+	"shaderUniformTexelBufferArrayDynamicIndexing",
+	"shaderStorageTexelBufferArrayDynamicIndexing",
+	"shaderUniformBufferArrayNonUniformIndexing",
+	"shaderSampledImageArrayNonUniformIndexing",
+	"shaderStorageBufferArrayNonUniformIndexing",
+	"shaderStorageImageArrayNonUniformIndexing",
+	"shaderInputAttachmentArrayNonUniformIndexing",
+	"shaderUniformTexelBufferArrayNonUniformIndexing",
+	"shaderStorageTexelBufferArrayNonUniformIndexing",
+	"descriptorBindingUniformBufferUpdateAfterBind",
+	"descriptorBindingSampledImageUpdateAfterBind",
+	"descriptorBindingStorageImageUpdateAfterBind",
+	"descriptorBindingStorageBufferUpdateAfterBind",
+	"descriptorBindingUniformTexelBufferUpdateAfterBind",
+	"descriptorBindingStorageTexelBufferUpdateAfterBind",
+	"descriptorBindingUpdateUnusedWhilePending",
+	"descriptorBindingPartiallyBound",
+	"descriptorBindingVariableDescriptorCount",
+	"runtimeDescriptorArray",
+	"samplerFilterMinmax",
+	"scalarBlockLayout",
+	"imagelessFramebuffer",
+	"uniformBufferStandardLayout",
+	"shaderSubgroupExtendedTypes",
+	"separateDepthStencilLayouts",
+	"hostQueryReset",
+	"timelineSemaphore",
+	"bufferDeviceAddress",
+	"bufferDeviceAddressCaptureReplay",
+	"bufferDeviceAddressMultiDevice",
+	"vulkanMemoryModel",
+	"vulkanMemoryModelDeviceScope",
+	"vulkanMemoryModelAvailabilityVisibilityChains",
+	"shaderOutputViewportIndex",
+	"shaderOutputLayer",
+	"subgroupBroadcastDynamicId",
+}
+
+// vulkan13FeatureNames lists the VkPhysicalDeviceVulkan13Features fields in
+// declaration order.
+var vulkan13FeatureNames = []string{
+	"robustImageAccess",
+	"inlineUniformBlock",
+	"descriptorBindingInlineUniformBlockUpdateAfterBind",
+	"pipelineCreationCacheControl",
+	"privateData",
+	"shaderDemoteToHelperInvocation",
+	"shaderTerminateInvocation",
+	"subgroupSizeControl",
+	"computeFullSubgroups",
+	"synchronization2",
+	"textureCompressionASTC_HDR",
+	"shaderZeroInitializeWorkgroupInvocations",
+	"dynamicRendering",
+	"shaderIntegerDotProduct",
+	"maintenance4",
+}
+
+// physicalDeviceFeatures2 has the C layout of VkPhysicalDeviceFeatures2;
+// vk.PhysicalDeviceFeatures2 carries Go-only fields and can't be chained.
+type physicalDeviceFeatures2 struct {
+	chainHeader
+	Features [55]vk.Bool32
+}
+
+// physicalDeviceVulkan11Features has the C layout of
+// VkPhysicalDeviceVulkan11Features.
+type physicalDeviceVulkan11Features struct {
+	chainHeader
+	Features [12]vk.Bool32
+}
+
+// physicalDeviceVulkan12Features has the C layout of
+// VkPhysicalDeviceVulkan12Features.
+type physicalDeviceVulkan12Features struct {
+	chainHeader
+	Features [47]vk.Bool32
+}
+
+// physicalDeviceVulkan13Features has the C layout of
+// VkPhysicalDeviceVulkan13Features.
+type physicalDeviceVulkan13Features struct {
+	chainHeader
+	Features [15]vk.Bool32
+}
+
+// ErrFeatures2NotSupported is returned by GetCoreFeatures when the instance
+// or the GPU only supports Vulkan 1.0, so vkGetPhysicalDeviceFeatures2
+// can't be used.
+var ErrFeatures2NotSupported = errors.New("vkGetPhysicalDeviceFeatures2 requires Vulkan 1.1")
+
+// CoreFeatures holds the features of the VkPhysicalDeviceVulkan1XFeatures
+// structures, keyed by field name. The map of a version the GPU doesn't
+// support is nil.
+type CoreFeatures struct {
+	Vulkan11 map[string]bool
+	Vulkan12 map[string]bool
+	Vulkan13 map[string]bool
+}
+
+// GetCoreFeatures queries the Vulkan 1.1, 1.2 and 1.3 features of the GPU
+// at gpuIndex through vkGetPhysicalDeviceFeatures2. The per-version
+// structures were added in Vulkan 1.2, so a 1.1 GPU reports none of them.
+func GetCoreFeatures(v *VulkanDeviceInfo, gpuIndex int) (CoreFeatures, error) {
+	var features CoreFeatures
+	gpu := v.gpuDevices[gpuIndex]
+	apiVersion := getDeviceProperties(gpu).ApiVersion
+	if v.apiVersion < vk.MakeVersion(1, 1, 0) || apiVersion < vk.MakeVersion(1, 1, 0) {
+		return features, ErrFeatures2NotSupported
+	}
+	// The structures are limited to what both the instance and the GPU
+	// support.
+	if v.apiVersion < apiVersion {
+		apiVersion = v.apiVersion
+	}
+
+	features2 := physicalDeviceFeatures2{chainHeader: chainHeader{SType: vk.StructureTypePhysicalDeviceFeatures2}}
+	vulkan11 := physicalDeviceVulkan11Features{chainHeader: chainHeader{SType: structureTypePhysicalDeviceVulkan11Features}}
+	vulkan12 := physicalDeviceVulkan12Features{chainHeader: chainHeader{SType: structureTypePhysicalDeviceVulkan12Features}}
+	vulkan13 := physicalDeviceVulkan13Features{chainHeader: chainHeader{SType: structureTypePhysicalDeviceVulkan13Features}}
+	var chain structChain
+	chain.add(unsafe.Pointer(&features2), unsafe.Sizeof(features2))
+	if apiVersion >= vk.MakeVersion(1, 2, 0) {
+		chain.add(unsafe.Pointer(&vulkan11), unsafe.Sizeof(vulkan11))
+		chain.add(unsafe.Pointer(&vulkan12), unsafe.Sizeof(vulkan12))
+	}
+	if apiVersion >= vk.MakeVersion(1, 3, 0) {
+		chain.add(unsafe.Pointer(&vulkan13), unsafe.Sizeof(vulkan13))
+	}
+	defer chain.free()
+	if !physicalDeviceQuery(v.instance, "vkGetPhysicalDeviceFeatures2", gpu, chain.link()) {
+		return features, ErrFeatures2NotSupported
+	}
+	chain.read()
+
+	if apiVersion >= vk.MakeVersion(1, 2, 0) {
+		features.Vulkan11 = featureMap(vulkan11FeatureNames, vulkan11.Features[:])
+		features.Vulkan12 = featureMap(vulkan12FeatureNames, vulkan12.Features[:])
+	}
+	if apiVersion >= vk.MakeVersion(1, 3, 0) {
+		features.Vulkan13 = featureMap(vulkan13FeatureNames, vulkan13.Features[:])
+	}
+	return features, nil
+}
+
+func featureMap(names []string, values []vk.Bool32) map[string]bool {
+	features := make(map[string]bool, len(names))
+	for i, name := range names {
+		features[name] = values[i].B()
+	}
+	return features
+}
+
+// PrintCoreFeatures prints the Vulkan 1.1, 1.2 and 1.3 features of the GPU
+// at gpuIndex grouped by core version. Nothing is printed for GPUs that
+// don't report them.
+func PrintCoreFeatures(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	features, err := GetCoreFeatures(v, gpuIndex)
+	if err != nil || features.Vulkan11 == nil {
+		return
+	}
+
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Core Features", gpuIndex))
+	addFeatureSection(table, "Vulkan 1.1", vulkan11FeatureNames, features.Vulkan11)
+	addFeatureSection(table, "Vulkan 1.2", vulkan12FeatureNames, features.Vulkan12)
+	addFeatureSection(table, "Vulkan 1.3", vulkan13FeatureNames, features.Vulkan13)
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
+
+func addFeatureSection(table *tablewriter.Table, name string, names []string, features map[string]bool) {
+	if features == nil {
+		return
+	}
+	addSection(table, name)
+	for _, feature := range names {
+		table.AddRow(feature, checkMark(features[feature]))
+	}
+}
@@ -0,0 +1,89 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// ErrTimeout is returned, possibly wrapped, when a wait for the GPU times
+// out (VK_TIMEOUT). The wait can be retried.
+var ErrTimeout = errors.New("timed out waiting for the GPU")
+
+// CreateFence creates a fence, in the signaled state if signaled is true.
+// Destroy it with DestroyFence.
+func CreateFence(v *VulkanDeviceInfo, signaled bool) (vk.Fence, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return vk.NullFence, ErrNoDevice
+	}
+	createInfo := &vk.FenceCreateInfo{SType: vk.StructureTypeFenceCreateInfo}
+	if signaled {
+		createInfo.Flags = vk.FenceCreateFlags(vk.FenceCreateSignaledBit)
+	}
+	var fence vk.Fence
+	if err := vk.Error(vk.CreateFence(v.device, createInfo, v.allocator, &fence)); err != nil {
+		err = fmt.Errorf("vkCreateFence failed with %s", err)
+		return vk.NullFence, err
+	}
+	return fence, nil
+}
+
+// WaitForFence waits up to timeoutNs nanoseconds for fence to be signaled.
+// It returns ErrTimeout if it isn't by then.
+func WaitForFence(v *VulkanDeviceInfo, fence vk.Fence, timeoutNs uint64) error {
+	return WaitForFences(v, []vk.Fence{fence}, true, timeoutNs)
+}
+
+// WaitForFences waits up to timeoutNs nanoseconds for every fence of fences
+// to be signaled, or for any of them if waitAll is false. It returns
+// ErrTimeout if they aren't by then.
+func WaitForFences(v *VulkanDeviceInfo, fences []vk.Fence, waitAll bool, timeoutNs uint64) error {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return ErrNoDevice
+	}
+	if len(fences) == 0 {
+		return nil
+	}
+	all := vk.Bool32(vk.False)
+	if waitAll {
+		all = vk.True
+	}
+	ret := vk.WaitForFences(v.device, uint32(len(fences)), fences, all, timeoutNs)
+	if ret == vk.Timeout {
+		return ErrTimeout
+	}
+	if err := vk.Error(ret); err != nil {
+		err = fmt.Errorf("vkWaitForFences failed with %s", err)
+		return err
+	}
+	return nil
+}
+
+// ResetFence returns fence to the unsignaled state.
+func ResetFence(v *VulkanDeviceInfo, fence vk.Fence) error {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return ErrNoDevice
+	}
+	if err := vk.Error(vk.ResetFences(v.device, 1, []vk.Fence{fence})); err != nil {
+		err = fmt.Errorf("vkResetFences failed with %s", err)
+		return err
+	}
+	return nil
+}
+
+// DestroyFence destroys fence. No queue submission may still use it.
+func DestroyFence(v *VulkanDeviceInfo, fence vk.Fence) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil || fence == vk.NullFence {
+		return
+	}
+	vk.DestroyFence(v.device, fence, v.allocator)
+}
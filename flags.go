@@ -0,0 +1,30 @@
+package vulkandevice
+
+import (
+	"strings"
+)
+
+// flagName maps a single bit of a Vulkan flags value to its display name.
+type flagName struct {
+	bit  uint32
+	name string
+}
+
+// flagNames returns the names of the bits set in flags, in table order.
+func flagNames(flags uint32, table []flagName) []string {
+	names := []string{}
+	for _, f := range table {
+		if flags&f.bit != 0 {
+			names = append(names, f.name)
+		}
+	}
+	return names
+}
+
+// joinFlags joins flag names with "|", or returns "None" if there are none.
+func joinFlags(names []string) string {
+	if len(names) == 0 {
+		return "None"
+	}
+	return strings.Join(names, "|")
+}
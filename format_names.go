@@ -0,0 +1,318 @@
+package vulkandevice
+
+import (
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// formatNames maps VkFormat values to their names without the VK_FORMAT_
+// prefix. Formats newer than the vulkan-go bindings are listed by value.
+var formatNames = map[vk.Format]string{
+	vk.FormatUndefined:                            "UNDEFINED",
+	vk.FormatR4g4UnormPack8:                       "R4G4_UNORM_PACK8",
+	vk.FormatR4g4b4a4UnormPack16:                  "R4G4B4A4_UNORM_PACK16",
+	vk.FormatB4g4r4a4UnormPack16:                  "B4G4R4A4_UNORM_PACK16",
+	vk.FormatR5g6b5UnormPack16:                    "R5G6B5_UNORM_PACK16",
+	vk.FormatB5g6r5UnormPack16:                    "B5G6R5_UNORM_PACK16",
+	vk.FormatR5g5b5a1UnormPack16:                  "R5G5B5A1_UNORM_PACK16",
+	vk.FormatB5g5r5a1UnormPack16:                  "B5G5R5A1_UNORM_PACK16",
+	vk.FormatA1r5g5b5UnormPack16:                  "A1R5G5B5_UNORM_PACK16",
+	vk.FormatR8Unorm:                              "R8_UNORM",
+	vk.FormatR8Snorm:                              "R8_SNORM",
+	vk.FormatR8Uscaled:                            "R8_USCALED",
+	vk.FormatR8Sscaled:                            "R8_SSCALED",
+	vk.FormatR8Uint:                               "R8_UINT",
+	vk.FormatR8Sint:                               "R8_SINT",
+	vk.FormatR8Srgb:                               "R8_SRGB",
+	vk.FormatR8g8Unorm:                            "R8G8_UNORM",
+	vk.FormatR8g8Snorm:                            "R8G8_SNORM",
+	vk.FormatR8g8Uscaled:                          "R8G8_USCALED",
+	vk.FormatR8g8Sscaled:                          "R8G8_SSCALED",
+	vk.FormatR8g8Uint:                             "R8G8_UINT",
+	vk.FormatR8g8Sint:                             "R8G8_SINT",
+	vk.FormatR8g8Srgb:                             "R8G8_SRGB",
+	vk.FormatR8g8b8Unorm:                          "R8G8B8_UNORM",
+	vk.FormatR8g8b8Snorm:                          "R8G8B8_SNORM",
+	vk.FormatR8g8b8Uscaled:                        "R8G8B8_USCALED",
+	vk.FormatR8g8b8Sscaled:                        "R8G8B8_SSCALED",
+	vk.FormatR8g8b8Uint:                           "R8G8B8_UINT",
+	vk.FormatR8g8b8Sint:                           "R8G8B8_SINT",
+	vk.FormatR8g8b8Srgb:                           "R8G8B8_SRGB",
+	vk.FormatB8g8r8Unorm:                          "B8G8R8_UNORM",
+	vk.FormatB8g8r8Snorm:                          "B8G8R8_SNORM",
+	vk.FormatB8g8r8Uscaled:                        "B8G8R8_USCALED",
+	vk.FormatB8g8r8Sscaled:                        "B8G8R8_SSCALED",
+	vk.FormatB8g8r8Uint:                           "B8G8R8_UINT",
+	vk.FormatB8g8r8Sint:                           "B8G8R8_SINT",
+	vk.FormatB8g8r8Srgb:                           "B8G8R8_SRGB",
+	vk.FormatR8g8b8a8Unorm:                        "R8G8B8A8_UNORM",
+	vk.FormatR8g8b8a8Snorm:                        "R8G8B8A8_SNORM",
+	vk.FormatR8g8b8a8Uscaled:                      "R8G8B8A8_USCALED",
+	vk.FormatR8g8b8a8Sscaled:                      "R8G8B8A8_SSCALED",
+	vk.FormatR8g8b8a8Uint:                         "R8G8B8A8_UINT",
+	vk.FormatR8g8b8a8Sint:                         "R8G8B8A8_SINT",
+	vk.FormatR8g8b8a8Srgb:                         "R8G8B8A8_SRGB",
+	vk.FormatB8g8r8a8Unorm:                        "B8G8R8A8_UNORM",
+	vk.FormatB8g8r8a8Snorm:                        "B8G8R8A8_SNORM",
+	vk.FormatB8g8r8a8Uscaled:                      "B8G8R8A8_USCALED",
+	vk.FormatB8g8r8a8Sscaled:                      "B8G8R8A8_SSCALED",
+	vk.FormatB8g8r8a8Uint:                         "B8G8R8A8_UINT",
+	vk.FormatB8g8r8a8Sint:                         "B8G8R8A8_SINT",
+	vk.FormatB8g8r8a8Srgb:                         "B8G8R8A8_SRGB",
+	vk.FormatA8b8g8r8UnormPack32:                  "A8B8G8R8_UNORM_PACK32",
+	vk.FormatA8b8g8r8SnormPack32:                  "A8B8G8R8_SNORM_PACK32",
+	vk.FormatA8b8g8r8UscaledPack32:                "A8B8G8R8_USCALED_PACK32",
+	vk.FormatA8b8g8r8SscaledPack32:                "A8B8G8R8_SSCALED_PACK32",
+	vk.FormatA8b8g8r8UintPack32:                   "A8B8G8R8_UINT_PACK32",
+	vk.FormatA8b8g8r8SintPack32:                   "A8B8G8R8_SINT_PACK32",
+	vk.FormatA8b8g8r8SrgbPack32:                   "A8B8G8R8_SRGB_PACK32",
+	vk.FormatA2r10g10b10UnormPack32:               "A2R10G10B10_UNORM_PACK32",
+	vk.FormatA2r10g10b10SnormPack32:               "A2R10G10B10_SNORM_PACK32",
+	vk.FormatA2r10g10b10UscaledPack32:             "A2R10G10B10_USCALED_PACK32",
+	vk.FormatA2r10g10b10SscaledPack32:             "A2R10G10B10_SSCALED_PACK32",
+	vk.FormatA2r10g10b10UintPack32:                "A2R10G10B10_UINT_PACK32",
+	vk.FormatA2r10g10b10SintPack32:                "A2R10G10B10_SINT_PACK32",
+	vk.FormatA2b10g10r10UnormPack32:               "A2B10G10R10_UNORM_PACK32",
+	vk.FormatA2b10g10r10SnormPack32:               "A2B10G10R10_SNORM_PACK32",
+	vk.FormatA2b10g10r10UscaledPack32:             "A2B10G10R10_USCALED_PACK32",
+	vk.FormatA2b10g10r10SscaledPack32:             "A2B10G10R10_SSCALED_PACK32",
+	vk.FormatA2b10g10r10UintPack32:                "A2B10G10R10_UINT_PACK32",
+	vk.FormatA2b10g10r10SintPack32:                "A2B10G10R10_SINT_PACK32",
+	vk.FormatR16Unorm:                             "R16_UNORM",
+	vk.FormatR16Snorm:                             "R16_SNORM",
+	vk.FormatR16Uscaled:                           "R16_USCALED",
+	vk.FormatR16Sscaled:                           "R16_SSCALED",
+	vk.FormatR16Uint:                              "R16_UINT",
+	vk.FormatR16Sint:                              "R16_SINT",
+	vk.FormatR16Sfloat:                            "R16_SFLOAT",
+	vk.FormatR16g16Unorm:                          "R16G16_UNORM",
+	vk.FormatR16g16Snorm:                          "R16G16_SNORM",
+	vk.FormatR16g16Uscaled:                        "R16G16_USCALED",
+	vk.FormatR16g16Sscaled:                        "R16G16_SSCALED",
+	vk.FormatR16g16Uint:                           "R16G16_UINT",
+	vk.FormatR16g16Sint:                           "R16G16_SINT",
+	vk.FormatR16g16Sfloat:                         "R16G16_SFLOAT",
+	vk.FormatR16g16b16Unorm:                       "R16G16B16_UNORM",
+	vk.FormatR16g16b16Snorm:                       "R16G16B16_SNORM",
+	vk.FormatR16g16b16Uscaled:                     "R16G16B16_USCALED",
+	vk.FormatR16g16b16Sscaled:                     "R16G16B16_SSCALED",
+	vk.FormatR16g16b16Uint:                        "R16G16B16_UINT",
+	vk.FormatR16g16b16Sint:                        "R16G16B16_SINT",
+	vk.FormatR16g16b16Sfloat:                      "R16G16B16_SFLOAT",
+	vk.FormatR16g16b16a16Unorm:                    "R16G16B16A16_UNORM",
+	vk.FormatR16g16b16a16Snorm:                    "R16G16B16A16_SNORM",
+	vk.FormatR16g16b16a16Uscaled:                  "R16G16B16A16_USCALED",
+	vk.FormatR16g16b16a16Sscaled:                  "R16G16B16A16_SSCALED",
+	vk.FormatR16g16b16a16Uint:                     "R16G16B16A16_UINT",
+	vk.FormatR16g16b16a16Sint:                     "R16G16B16A16_SINT",
+	vk.FormatR16g16b16a16Sfloat:                   "R16G16B16A16_SFLOAT",
+	vk.FormatR32Uint:                              "R32_UINT",
+	vk.FormatR32Sint:                              "R32_SINT",
+	vk.FormatR32Sfloat:                            "R32_SFLOAT",
+	vk.FormatR32g32Uint:                           "R32G32_UINT",
+	vk.FormatR32g32Sint:                           "R32G32_SINT",
+	vk.FormatR32g32Sfloat:                         "R32G32_SFLOAT",
+	vk.FormatR32g32b32Uint:                        "R32G32B32_UINT",
+	vk.FormatR32g32b32Sint:                        "R32G32B32_SINT",
+	vk.FormatR32g32b32Sfloat:                      "R32G32B32_SFLOAT",
+	vk.FormatR32g32b32a32Uint:                     "R32G32B32A32_UINT",
+	vk.FormatR32g32b32a32Sint:                     "R32G32B32A32_SINT",
+	vk.FormatR32g32b32a32Sfloat:                   "R32G32B32A32_SFLOAT",
+	vk.FormatR64Uint:                              "R64_UINT",
+	vk.FormatR64Sint:                              "R64_SINT",
+	vk.FormatR64Sfloat:                            "R64_SFLOAT",
+	vk.FormatR64g64Uint:                           "R64G64_UINT",
+	vk.FormatR64g64Sint:                           "R64G64_SINT",
+	vk.FormatR64g64Sfloat:                         "R64G64_SFLOAT",
+	vk.FormatR64g64b64Uint:                        "R64G64B64_UINT",
+	vk.FormatR64g64b64Sint:                        "R64G64B64_SINT",
+	vk.FormatR64g64b64Sfloat:                      "R64G64B64_SFLOAT",
+	vk.FormatR64g64b64a64Uint:                     "R64G64B64A64_UINT",
+	vk.FormatR64g64b64a64Sint:                     "R64G64B64A64_SINT",
+	vk.FormatR64g64b64a64Sfloat:                   "R64G64B64A64_SFLOAT",
+	vk.FormatB10g11r11UfloatPack32:                "B10G11R11_UFLOAT_PACK32",
+	vk.FormatE5b9g9r9UfloatPack32:                 "E5B9G9R9_UFLOAT_PACK32",
+	vk.FormatD16Unorm:                             "D16_UNORM",
+	vk.FormatX8D24UnormPack32:                     "X8_D24_UNORM_PACK32",
+	vk.FormatD32Sfloat:                            "D32_SFLOAT",
+	vk.FormatS8Uint:                               "S8_UINT",
+	vk.FormatD16UnormS8Uint:                       "D16_UNORM_S8_UINT",
+	vk.FormatD24UnormS8Uint:                       "D24_UNORM_S8_UINT",
+	vk.FormatD32SfloatS8Uint:                      "D32_SFLOAT_S8_UINT",
+	vk.FormatBc1RgbUnormBlock:                     "BC1_RGB_UNORM_BLOCK",
+	vk.FormatBc1RgbSrgbBlock:                      "BC1_RGB_SRGB_BLOCK",
+	vk.FormatBc1RgbaUnormBlock:                    "BC1_RGBA_UNORM_BLOCK",
+	vk.FormatBc1RgbaSrgbBlock:                     "BC1_RGBA_SRGB_BLOCK",
+	vk.FormatBc2UnormBlock:                        "BC2_UNORM_BLOCK",
+	vk.FormatBc2SrgbBlock:                         "BC2_SRGB_BLOCK",
+	vk.FormatBc3UnormBlock:                        "BC3_UNORM_BLOCK",
+	vk.FormatBc3SrgbBlock:                         "BC3_SRGB_BLOCK",
+	vk.FormatBc4UnormBlock:                        "BC4_UNORM_BLOCK",
+	vk.FormatBc4SnormBlock:                        "BC4_SNORM_BLOCK",
+	vk.FormatBc5UnormBlock:                        "BC5_UNORM_BLOCK",
+	vk.FormatBc5SnormBlock:                        "BC5_SNORM_BLOCK",
+	vk.FormatBc6hUfloatBlock:                      "BC6H_UFLOAT_BLOCK",
+	vk.FormatBc6hSfloatBlock:                      "BC6H_SFLOAT_BLOCK",
+	vk.FormatBc7UnormBlock:                        "BC7_UNORM_BLOCK",
+	vk.FormatBc7SrgbBlock:                         "BC7_SRGB_BLOCK",
+	vk.FormatEtc2R8g8b8UnormBlock:                 "ETC2_R8G8B8_UNORM_BLOCK",
+	vk.FormatEtc2R8g8b8SrgbBlock:                  "ETC2_R8G8B8_SRGB_BLOCK",
+	vk.FormatEtc2R8g8b8a1UnormBlock:               "ETC2_R8G8B8A1_UNORM_BLOCK",
+	vk.FormatEtc2R8g8b8a1SrgbBlock:                "ETC2_R8G8B8A1_SRGB_BLOCK",
+	vk.FormatEtc2R8g8b8a8UnormBlock:               "ETC2_R8G8B8A8_UNORM_BLOCK",
+	vk.FormatEtc2R8g8b8a8SrgbBlock:                "ETC2_R8G8B8A8_SRGB_BLOCK",
+	vk.FormatEacR11UnormBlock:                     "EAC_R11_UNORM_BLOCK",
+	vk.FormatEacR11SnormBlock:                     "EAC_R11_SNORM_BLOCK",
+	vk.FormatEacR11g11UnormBlock:                  "EAC_R11G11_UNORM_BLOCK",
+	vk.FormatEacR11g11SnormBlock:                  "EAC_R11G11_SNORM_BLOCK",
+	vk.FormatAstc4x4UnormBlock:                    "ASTC_4x4_UNORM_BLOCK",
+	vk.FormatAstc4x4SrgbBlock:                     "ASTC_4x4_SRGB_BLOCK",
+	vk.FormatAstc5x4UnormBlock:                    "ASTC_5x4_UNORM_BLOCK",
+	vk.FormatAstc5x4SrgbBlock:                     "ASTC_5x4_SRGB_BLOCK",
+	vk.FormatAstc5x5UnormBlock:                    "ASTC_5x5_UNORM_BLOCK",
+	vk.FormatAstc5x5SrgbBlock:                     "ASTC_5x5_SRGB_BLOCK",
+	vk.FormatAstc6x5UnormBlock:                    "ASTC_6x5_UNORM_BLOCK",
+	vk.FormatAstc6x5SrgbBlock:                     "ASTC_6x5_SRGB_BLOCK",
+	vk.FormatAstc6x6UnormBlock:                    "ASTC_6x6_UNORM_BLOCK",
+	vk.FormatAstc6x6SrgbBlock:                     "ASTC_6x6_SRGB_BLOCK",
+	vk.FormatAstc8x5UnormBlock:                    "ASTC_8x5_UNORM_BLOCK",
+	vk.FormatAstc8x5SrgbBlock:                     "ASTC_8x5_SRGB_BLOCK",
+	vk.FormatAstc8x6UnormBlock:                    "ASTC_8x6_UNORM_BLOCK",
+	vk.FormatAstc8x6SrgbBlock:                     "ASTC_8x6_SRGB_BLOCK",
+	vk.FormatAstc8x8UnormBlock:                    "ASTC_8x8_UNORM_BLOCK",
+	vk.FormatAstc8x8SrgbBlock:                     "ASTC_8x8_SRGB_BLOCK",
+	vk.FormatAstc10x5UnormBlock:                   "ASTC_10x5_UNORM_BLOCK",
+	vk.FormatAstc10x5SrgbBlock:                    "ASTC_10x5_SRGB_BLOCK",
+	vk.FormatAstc10x6UnormBlock:                   "ASTC_10x6_UNORM_BLOCK",
+	vk.FormatAstc10x6SrgbBlock:                    "ASTC_10x6_SRGB_BLOCK",
+	vk.FormatAstc10x8UnormBlock:                   "ASTC_10x8_UNORM_BLOCK",
+	vk.FormatAstc10x8SrgbBlock:                    "ASTC_10x8_SRGB_BLOCK",
+	vk.FormatAstc10x10UnormBlock:                  "ASTC_10x10_UNORM_BLOCK",
+	vk.FormatAstc10x10SrgbBlock:                   "ASTC_10x10_SRGB_BLOCK",
+	vk.FormatAstc12x10UnormBlock:                  "ASTC_12x10_UNORM_BLOCK",
+	vk.FormatAstc12x10SrgbBlock:                   "ASTC_12x10_SRGB_BLOCK",
+	vk.FormatAstc12x12UnormBlock:                  "ASTC_12x12_UNORM_BLOCK",
+	vk.FormatAstc12x12SrgbBlock:                   "ASTC_12x12_SRGB_BLOCK",
+	vk.FormatG8b8g8r8422Unorm:                     "G8B8G8R8_422_UNORM",
+	vk.FormatB8g8r8g8422Unorm:                     "B8G8R8G8_422_UNORM",
+	vk.FormatG8B8R83plane420Unorm:                 "G8_B8_R8_3PLANE_420_UNORM",
+	vk.FormatG8B8r82plane420Unorm:                 "G8_B8R8_2PLANE_420_UNORM",
+	vk.FormatG8B8R83plane422Unorm:                 "G8_B8_R8_3PLANE_422_UNORM",
+	vk.FormatG8B8r82plane422Unorm:                 "G8_B8R8_2PLANE_422_UNORM",
+	vk.FormatG8B8R83plane444Unorm:                 "G8_B8_R8_3PLANE_444_UNORM",
+	vk.FormatR10x6UnormPack16:                     "R10X6_UNORM_PACK16",
+	vk.FormatR10x6g10x6Unorm2pack16:               "R10X6G10X6_UNORM_2PACK16",
+	vk.FormatR10x6g10x6b10x6a10x6Unorm4pack16:     "R10X6G10X6B10X6A10X6_UNORM_4PACK16",
+	vk.FormatG10x6b10x6g10x6r10x6422Unorm4pack16:  "G10X6B10X6G10X6R10X6_422_UNORM_4PACK16",
+	vk.FormatB10x6g10x6r10x6g10x6422Unorm4pack16:  "B10X6G10X6R10X6G10X6_422_UNORM_4PACK16",
+	vk.FormatG10x6B10x6R10x63plane420Unorm3pack16: "G10X6_B10X6_R10X6_3PLANE_420_UNORM_3PACK16",
+	vk.FormatG10x6B10x6r10x62plane420Unorm3pack16: "G10X6_B10X6R10X6_2PLANE_420_UNORM_3PACK16",
+	vk.FormatG10x6B10x6R10x63plane422Unorm3pack16: "G10X6_B10X6_R10X6_3PLANE_422_UNORM_3PACK16",
+	vk.FormatG10x6B10x6r10x62plane422Unorm3pack16: "G10X6_B10X6R10X6_2PLANE_422_UNORM_3PACK16",
+	vk.FormatG10x6B10x6R10x63plane444Unorm3pack16: "G10X6_B10X6_R10X6_3PLANE_444_UNORM_3PACK16",
+	vk.FormatR12x4UnormPack16:                     "R12X4_UNORM_PACK16",
+	vk.FormatR12x4g12x4Unorm2pack16:               "R12X4G12X4_UNORM_2PACK16",
+	vk.FormatR12x4g12x4b12x4a12x4Unorm4pack16:     "R12X4G12X4B12X4A12X4_UNORM_4PACK16",
+	vk.FormatG12x4b12x4g12x4r12x4422Unorm4pack16:  "G12X4B12X4G12X4R12X4_422_UNORM_4PACK16",
+	vk.FormatB12x4g12x4r12x4g12x4422Unorm4pack16:  "B12X4G12X4R12X4G12X4_422_UNORM_4PACK16",
+	vk.FormatG12x4B12x4R12x43plane420Unorm3pack16: "G12X4_B12X4_R12X4_3PLANE_420_UNORM_3PACK16",
+	vk.FormatG12x4B12x4r12x42plane420Unorm3pack16: "G12X4_B12X4R12X4_2PLANE_420_UNORM_3PACK16",
+	vk.FormatG12x4B12x4R12x43plane422Unorm3pack16: "G12X4_B12X4_R12X4_3PLANE_422_UNORM_3PACK16",
+	vk.FormatG12x4B12x4r12x42plane422Unorm3pack16: "G12X4_B12X4R12X4_2PLANE_422_UNORM_3PACK16",
+	vk.FormatG12x4B12x4R12x43plane444Unorm3pack16: "G12X4_B12X4_R12X4_3PLANE_444_UNORM_3PACK16",
+	vk.FormatG16b16g16r16422Unorm:                 "G16B16G16R16_422_UNORM",
+	vk.FormatB16g16r16g16422Unorm:                 "B16G16R16G16_422_UNORM",
+	vk.FormatG16B16R163plane420Unorm:              "G16_B16_R16_3PLANE_420_UNORM",
+	vk.FormatG16B16r162plane420Unorm:              "G16_B16R16_2PLANE_420_UNORM",
+	vk.FormatG16B16R163plane422Unorm:              "G16_B16_R16_3PLANE_422_UNORM",
+	vk.FormatG16B16r162plane422Unorm:              "G16_B16R16_2PLANE_422_UNORM",
+	vk.FormatG16B16R163plane444Unorm:              "G16_B16_R16_3PLANE_444_UNORM",
+	vk.FormatPvrtc12bppUnormBlockImg:              "PVRTC1_2BPP_UNORM_BLOCK",
+	vk.FormatPvrtc14bppUnormBlockImg:              "PVRTC1_4BPP_UNORM_BLOCK",
+	vk.FormatPvrtc22bppUnormBlockImg:              "PVRTC2_2BPP_UNORM_BLOCK",
+	vk.FormatPvrtc24bppUnormBlockImg:              "PVRTC2_4BPP_UNORM_BLOCK",
+	vk.FormatPvrtc12bppSrgbBlockImg:               "PVRTC1_2BPP_SRGB_BLOCK",
+	vk.FormatPvrtc14bppSrgbBlockImg:               "PVRTC1_4BPP_SRGB_BLOCK",
+	vk.FormatPvrtc22bppSrgbBlockImg:               "PVRTC2_2BPP_SRGB_BLOCK",
+	vk.FormatPvrtc24bppSrgbBlockImg:               "PVRTC2_4BPP_SRGB_BLOCK",
+	vk.Format(1000066000):                         "ASTC_4x4_SFLOAT_BLOCK",
+	vk.Format(1000066001):                         "ASTC_5x4_SFLOAT_BLOCK",
+	vk.Format(1000066002):                         "ASTC_5x5_SFLOAT_BLOCK",
+	vk.Format(1000066003):                         "ASTC_6x5_SFLOAT_BLOCK",
+	vk.Format(1000066004):                         "ASTC_6x6_SFLOAT_BLOCK",
+	vk.Format(1000066005):                         "ASTC_8x5_SFLOAT_BLOCK",
+	vk.Format(1000066006):                         "ASTC_8x6_SFLOAT_BLOCK",
+	vk.Format(1000066007):                         "ASTC_8x8_SFLOAT_BLOCK",
+	vk.Format(1000066008):                         "ASTC_10x5_SFLOAT_BLOCK",
+	vk.Format(1000066009):                         "ASTC_10x6_SFLOAT_BLOCK",
+	vk.Format(1000066010):                         "ASTC_10x8_SFLOAT_BLOCK",
+	vk.Format(1000066011):                         "ASTC_10x10_SFLOAT_BLOCK",
+	vk.Format(1000066012):                         "ASTC_12x10_SFLOAT_BLOCK",
+	vk.Format(1000066013):                         "ASTC_12x12_SFLOAT_BLOCK",
+	vk.Format(1000330000):                         "G8_B8R8_2PLANE_444_UNORM",
+	vk.Format(1000330001):                         "G10X6_B10X6R10X6_2PLANE_444_UNORM_3PACK16",
+	vk.Format(1000330002):                         "G12X4_B12X4R12X4_2PLANE_444_UNORM_3PACK16",
+	vk.Format(1000330003):                         "G16_B16R16_2PLANE_444_UNORM",
+	vk.Format(1000340000):                         "A4R4G4B4_UNORM_PACK16",
+	vk.Format(1000340001):                         "A4B4G4R4_UNORM_PACK16",
+}
+
+// colorSpaceNames maps VkColorSpaceKHR values to their names without the
+// VK_COLOR_SPACE_ prefix and vendor suffix.
+var colorSpaceNames = map[vk.ColorSpace]string{
+	vk.ColorSpaceSrgbNonlinear:         "SRGB_NONLINEAR",
+	vk.ColorSpaceDisplayP3Nonlinear:    "DISPLAY_P3_NONLINEAR",
+	vk.ColorSpaceExtendedSrgbLinear:    "EXTENDED_SRGB_LINEAR",
+	vk.ColorSpaceDciP3Linear:           "DCI_P3_LINEAR",
+	vk.ColorSpaceDciP3Nonlinear:        "DCI_P3_NONLINEAR",
+	vk.ColorSpaceBt709Linear:           "BT709_LINEAR",
+	vk.ColorSpaceBt709Nonlinear:        "BT709_NONLINEAR",
+	vk.ColorSpaceBt2020Linear:          "BT2020_LINEAR",
+	vk.ColorSpaceHdr10St2084:           "HDR10_ST2084",
+	vk.ColorSpaceDolbyvision:           "DOLBYVISION",
+	vk.ColorSpaceHdr10Hlg:              "HDR10_HLG",
+	vk.ColorSpaceAdobergbLinear:        "ADOBERGB_LINEAR",
+	vk.ColorSpaceAdobergbNonlinear:     "ADOBERGB_NONLINEAR",
+	vk.ColorSpacePassThrough:           "PASS_THROUGH",
+	vk.ColorSpaceExtendedSrgbNonlinear: "EXTENDED_SRGB_NONLINEAR",
+	vk.ColorSpace(1000213000):          "DISPLAY_NATIVE_AMD",
+}
+
+// presentModeNames maps VkPresentModeKHR values to their names without the
+// VK_PRESENT_MODE_ prefix and vendor suffix.
+var presentModeNames = map[vk.PresentMode]string{
+	vk.PresentModeImmediate:               "IMMEDIATE",
+	vk.PresentModeMailbox:                 "MAILBOX",
+	vk.PresentModeFifo:                    "FIFO",
+	vk.PresentModeFifoRelaxed:             "FIFO_RELAXED",
+	vk.PresentModeSharedDemandRefresh:     "SHARED_DEMAND_REFRESH",
+	vk.PresentModeSharedContinuousRefresh: "SHARED_CONTINUOUS_REFRESH",
+}
+
+// FormatName returns the name of format, such as "B8G8R8A8_SRGB", or its
+// value if the format is unknown.
+func FormatName(format vk.Format) string {
+	if name, ok := formatNames[format]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown (%d)", format)
+}
+
+// ColorSpaceName returns the name of colorSpace, such as
+// "HDR10_ST2084", or its value if the color space is unknown.
+func ColorSpaceName(colorSpace vk.ColorSpace) string {
+	if name, ok := colorSpaceNames[colorSpace]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown (%d)", colorSpace)
+}
+
+// PresentModeName returns the name of mode, such as "MAILBOX", or its value
+// if the present mode is unknown.
+func PresentModeName(mode vk.PresentMode) string {
+	if name, ok := presentModeNames[mode]; ok {
+		return name
+	}
+	return fmt.Sprintf("Unknown (%d)", mode)
+}
@@ -0,0 +1,121 @@
+package vulkandevice
+
+import (
+	"testing"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+func TestFormatName(t *testing.T) {
+	tests := []struct {
+		format vk.Format
+		want   string
+	}{
+		{vk.FormatUndefined, "UNDEFINED"},
+		{vk.FormatB8g8r8a8Srgb, "B8G8R8A8_SRGB"},
+		{vk.FormatR16g16b16a16Sfloat, "R16G16B16A16_SFLOAT"},
+		{vk.FormatD24UnormS8Uint, "D24_UNORM_S8_UINT"},
+		{vk.FormatBc7SrgbBlock, "BC7_SRGB_BLOCK"},
+		{vk.Format(999999), "Unknown (999999)"},
+		{vk.Format(-1), "Unknown (-1)"},
+	}
+	for _, tt := range tests {
+		if got := FormatName(tt.format); got != tt.want {
+			t.Errorf("FormatName(%d) = %q, want %q", tt.format, got, tt.want)
+		}
+	}
+}
+
+func TestColorSpaceName(t *testing.T) {
+	tests := []struct {
+		colorSpace vk.ColorSpace
+		want       string
+	}{
+		{vk.ColorSpaceSrgbNonlinear, "SRGB_NONLINEAR"},
+		{vk.ColorSpaceDisplayP3Nonlinear, "DISPLAY_P3_NONLINEAR"},
+		{vk.ColorSpaceHdr10St2084, "HDR10_ST2084"},
+		{vk.ColorSpace(12345), "Unknown (12345)"},
+	}
+	for _, tt := range tests {
+		if got := ColorSpaceName(tt.colorSpace); got != tt.want {
+			t.Errorf("ColorSpaceName(%d) = %q, want %q", tt.colorSpace, got, tt.want)
+		}
+	}
+}
+
+func TestPresentModeName(t *testing.T) {
+	tests := []struct {
+		mode vk.PresentMode
+		want string
+	}{
+		{vk.PresentModeImmediate, "IMMEDIATE"},
+		{vk.PresentModeMailbox, "MAILBOX"},
+		{vk.PresentModeFifo, "FIFO"},
+		{vk.PresentModeFifoRelaxed, "FIFO_RELAXED"},
+		{vk.PresentModeSharedContinuousRefresh, "SHARED_CONTINUOUS_REFRESH"},
+		{vk.PresentMode(42), "Unknown (42)"},
+	}
+	for _, tt := range tests {
+		if got := PresentModeName(tt.mode); got != tt.want {
+			t.Errorf("PresentModeName(%d) = %q, want %q", tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestLookupFormat(t *testing.T) {
+	for format, name := range formatNames {
+		got, ok := LookupFormat(name)
+		if !ok || got != format {
+			t.Errorf("LookupFormat(%q) = %d, %t, want %d, true", name, got, ok, format)
+		}
+	}
+	tests := []struct {
+		name string
+		want vk.Format
+		ok   bool
+	}{
+		{"r8g8b8a8_unorm", vk.FormatR8g8b8a8Unorm, true},
+		{"VK_FORMAT_B8G8R8A8_SRGB", vk.FormatB8g8r8a8Srgb, true},
+		{"vk_format_d32_sfloat", vk.FormatD32Sfloat, true},
+		{"astc_8x8_srgb_block", vk.FormatAstc8x8SrgbBlock, true},
+		{"R8G8B8A8", vk.FormatUndefined, false},
+		{"", vk.FormatUndefined, false},
+	}
+	for _, tt := range tests {
+		got, ok := LookupFormat(tt.name)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("LookupFormat(%q) = %d, %t, want %d, %t", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestHasStencilComponent(t *testing.T) {
+	tests := []struct {
+		format vk.Format
+		want   bool
+	}{
+		{vk.FormatS8Uint, true},
+		{vk.FormatD16UnormS8Uint, true},
+		{vk.FormatD24UnormS8Uint, true},
+		{vk.FormatD32SfloatS8Uint, true},
+		{vk.FormatD16Unorm, false},
+		{vk.FormatD32Sfloat, false},
+		{vk.FormatX8D24UnormPack32, false},
+		{vk.FormatR8g8b8a8Sscaled, false},
+		{vk.FormatUndefined, false},
+		{vk.Format(999999), false},
+	}
+	for _, tt := range tests {
+		if got := HasStencilComponent(tt.format); got != tt.want {
+			t.Errorf("HasStencilComponent(%s) = %t, want %t", FormatName(tt.format), got, tt.want)
+		}
+	}
+}
+
+func TestCloseFormatNames(t *testing.T) {
+	got := CloseFormatNames("astc_8x8", 2)
+	want := []string{"ASTC_8x8_SFLOAT_BLOCK", "ASTC_8x8_SRGB_BLOCK"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("CloseFormatNames(\"astc_8x8\", 2) = %q, want %q", got, want)
+	}
+}
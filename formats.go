@@ -0,0 +1,291 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// knownFormats returns every format in formatNames in ascending order.
+func knownFormats() []vk.Format {
+	formats := make([]vk.Format, 0, len(formatNames))
+	for format := range formatNames {
+		formats = append(formats, format)
+	}
+	sort.Slice(formats, func(i, j int) bool { return formats[i] < formats[j] })
+	return formats
+}
+
+// GetFormatProperties returns the features gpu supports for format with
+// linear and optimal tiling and in buffers.
+func GetFormatProperties(gpu vk.PhysicalDevice, format vk.Format) vk.FormatProperties {
+	var properties vk.FormatProperties
+	vk.GetPhysicalDeviceFormatProperties(gpu, format, &properties)
+	properties.Deref()
+	return properties
+}
+
+// SupportsFormat reports whether gpu supports all of features for images of
+// format with the given tiling.
+func SupportsFormat(gpu vk.PhysicalDevice, format vk.Format, tiling vk.ImageTiling, features vk.FormatFeatureFlags) bool {
+	properties := GetFormatProperties(gpu, format)
+	supported := properties.OptimalTilingFeatures
+	if tiling == vk.ImageTilingLinear {
+		supported = properties.LinearTilingFeatures
+	}
+	return supported&features == features
+}
+
+// ErrFormatNotSupported is returned by GetImageFormatProperties when the
+// GPU can't create images with the given parameters.
+var ErrFormatNotSupported = errors.New("format not supported")
+
+// GetImageFormatProperties returns the limits of images gpu can create
+// with format, type, tiling, usage and create flags, for validating image
+// parameters before vkCreateImage:
+//
+//   - MaxExtent is the largest width, height and depth.
+//   - MaxMipLevels is the largest mip level count, 1 if mipmaps aren't
+//     supported.
+//   - MaxArrayLayers is the largest array layer count.
+//   - SampleCounts are the supported sample counts, VK_SAMPLE_COUNT_1_BIT
+//     only for linear tiling.
+//   - MaxResourceSize is an upper bound of the image size in bytes.
+//
+// It returns ErrFormatNotSupported for VK_ERROR_FORMAT_NOT_SUPPORTED.
+func GetImageFormatProperties(gpu vk.PhysicalDevice, format vk.Format, imgType vk.ImageType, tiling vk.ImageTiling,
+	usage vk.ImageUsageFlags, flags vk.ImageCreateFlags) (vk.ImageFormatProperties, error) {
+
+	var properties vk.ImageFormatProperties
+	ret := vk.GetPhysicalDeviceImageFormatProperties(gpu, format, imgType, tiling, usage, flags, &properties)
+	if ret == vk.ErrorFormatNotSupported {
+		err := fmt.Errorf("%w: %s", ErrFormatNotSupported, FormatName(format))
+		return properties, err
+	}
+	if err := vk.Error(ret); err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceImageFormatProperties failed with %s", err)
+		return properties, err
+	}
+	properties.Deref()
+	properties.MaxExtent.Deref()
+	return properties, nil
+}
+
+// CommonFormats are the color, depth and compressed formats applications
+// use most, for PrintFormatSupport.
+var CommonFormats = []vk.Format{
+	vk.FormatR8Unorm,
+	vk.FormatR8g8Unorm,
+	vk.FormatR8g8b8a8Unorm,
+	vk.FormatR8g8b8a8Srgb,
+	vk.FormatB8g8r8a8Unorm,
+	vk.FormatB8g8r8a8Srgb,
+	vk.FormatA2b10g10r10UnormPack32,
+	vk.FormatR16g16b16a16Sfloat,
+	vk.FormatR32Sfloat,
+	vk.FormatR32g32Sfloat,
+	vk.FormatR32g32b32Sfloat,
+	vk.FormatR32g32b32a32Sfloat,
+	vk.FormatR32Uint,
+	vk.FormatB10g11r11UfloatPack32,
+	vk.FormatD16Unorm,
+	vk.FormatX8D24UnormPack32,
+	vk.FormatD32Sfloat,
+	vk.FormatD24UnormS8Uint,
+	vk.FormatD32SfloatS8Uint,
+	vk.FormatBc1RgbaUnormBlock,
+	vk.FormatBc3UnormBlock,
+	vk.FormatBc7UnormBlock,
+	vk.FormatEtc2R8g8b8a8UnormBlock,
+	vk.FormatAstc4x4UnormBlock,
+}
+
+// LookupFormat returns the format called name, ignoring case and an optional
+// VK_FORMAT_ prefix, such as "r16g16b16a16_sfloat".
+func LookupFormat(name string) (vk.Format, bool) {
+	// ASTC names keep their lowercase x, such as ASTC_8x8_UNORM_BLOCK.
+	name = strings.TrimPrefix(strings.ToUpper(name), "VK_FORMAT_")
+	for format, formatName := range formatNames {
+		if strings.ToUpper(formatName) == name {
+			return format, true
+		}
+	}
+	return vk.FormatUndefined, false
+}
+
+// CloseFormatNames returns up to max known format names containing name or
+// within a small edit distance of it, closest first.
+func CloseFormatNames(name string, max int) []string {
+	name = strings.TrimPrefix(strings.ToUpper(name), "VK_FORMAT_")
+	type match struct {
+		name     string
+		distance int
+	}
+	var matches []match
+	for _, formatName := range formatNames {
+		upper := strings.ToUpper(formatName)
+		distance := editDistance(name, upper)
+		if strings.Contains(upper, name) {
+			distance = 0
+		}
+		if distance <= 3 {
+			matches = append(matches, match{formatName, distance})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+	var names []string
+	for i := 0; i < len(matches) && i < max; i++ {
+		names = append(names, matches[i].name)
+	}
+	return names
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(minInt(previous[j]+1, current[j-1]+1), previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// PrintFormatProperties prints the linear tiling, optimal tiling and buffer
+// features of each known format on the GPU at gpuIndex. Formats without any
+// supported feature are skipped unless all is set.
+func PrintFormatProperties(w io.Writer, v *VulkanDeviceInfo, gpuIndex int, all bool) {
+	gpu := v.gpuDevices[gpuIndex]
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Format Properties", gpuIndex))
+	table.AddHeaders("Format", "Linear Tiling", "Optimal Tiling", "Buffer")
+	for _, format := range knownFormats() {
+		if format == vk.FormatUndefined {
+			continue
+		}
+		properties := GetFormatProperties(gpu, format)
+		if !all && properties.LinearTilingFeatures == 0 &&
+			properties.OptimalTilingFeatures == 0 && properties.BufferFeatures == 0 {
+			continue
+		}
+		table.AddRow(FormatName(format), formatFeatureFlags(properties.LinearTilingFeatures),
+			formatFeatureFlags(properties.OptimalTilingFeatures), formatFeatureFlags(properties.BufferFeatures))
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
+
+// PrintFormatSupport prints the linear tiling, optimal tiling and buffer
+// features of each of formats on the GPU at gpuIndex, such as
+// CommonFormats.
+func PrintFormatSupport(w io.Writer, v *VulkanDeviceInfo, gpuIndex int, formats []vk.Format) {
+	gpu := v.gpuDevices[gpuIndex]
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Format Support", gpuIndex))
+	table.AddHeaders("Format", "Linear Tiling", "Optimal Tiling", "Buffer")
+	for _, format := range formats {
+		properties := GetFormatProperties(gpu, format)
+		table.AddRow(FormatName(format), formatFeatureFlags(properties.LinearTilingFeatures),
+			formatFeatureFlags(properties.OptimalTilingFeatures), formatFeatureFlags(properties.BufferFeatures))
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
+
+var formatFeatureFlagTable = []flagName{
+	{uint32(vk.FormatFeatureSampledImageBit), "SAMPLED_IMAGE"},
+	{uint32(vk.FormatFeatureStorageImageBit), "STORAGE_IMAGE"},
+	{uint32(vk.FormatFeatureStorageImageAtomicBit), "STORAGE_IMAGE_ATOMIC"},
+	{uint32(vk.FormatFeatureUniformTexelBufferBit), "UNIFORM_TEXEL_BUFFER"},
+	{uint32(vk.FormatFeatureStorageTexelBufferBit), "STORAGE_TEXEL_BUFFER"},
+	{uint32(vk.FormatFeatureStorageTexelBufferAtomicBit), "STORAGE_TEXEL_BUFFER_ATOMIC"},
+	{uint32(vk.FormatFeatureVertexBufferBit), "VERTEX_BUFFER"},
+	{uint32(vk.FormatFeatureColorAttachmentBit), "COLOR_ATTACHMENT"},
+	{uint32(vk.FormatFeatureColorAttachmentBlendBit), "COLOR_ATTACHMENT_BLEND"},
+	{uint32(vk.FormatFeatureDepthStencilAttachmentBit), "DEPTH_STENCIL_ATTACHMENT"},
+	{uint32(vk.FormatFeatureBlitSrcBit), "BLIT_SRC"},
+	{uint32(vk.FormatFeatureBlitDstBit), "BLIT_DST"},
+	{uint32(vk.FormatFeatureSampledImageFilterLinearBit), "SAMPLED_IMAGE_FILTER_LINEAR"},
+	{uint32(vk.FormatFeatureSampledImageFilterCubicBitImg), "SAMPLED_IMAGE_FILTER_CUBIC"},
+	{uint32(vk.FormatFeatureTransferSrcBit), "TRANSFER_SRC"},
+	{uint32(vk.FormatFeatureTransferDstBit), "TRANSFER_DST"},
+	{uint32(vk.FormatFeatureSampledImageFilterMinmaxBit), "SAMPLED_IMAGE_FILTER_MINMAX"},
+	{uint32(vk.FormatFeatureMidpointChromaSamplesBit), "MIDPOINT_CHROMA_SAMPLES"},
+	{uint32(vk.FormatFeatureSampledImageYcbcrConversionLinearFilterBit), "SAMPLED_IMAGE_YCBCR_CONVERSION_LINEAR_FILTER"},
+	{uint32(vk.FormatFeatureSampledImageYcbcrConversionSeparateReconstructionFilterBit), "SAMPLED_IMAGE_YCBCR_CONVERSION_SEPARATE_RECONSTRUCTION_FILTER"},
+	{uint32(vk.FormatFeatureSampledImageYcbcrConversionChromaReconstructionExplicitBit), "SAMPLED_IMAGE_YCBCR_CONVERSION_CHROMA_RECONSTRUCTION_EXPLICIT"},
+	{uint32(vk.FormatFeatureSampledImageYcbcrConversionChromaReconstructionExplicitForceableBit), "SAMPLED_IMAGE_YCBCR_CONVERSION_CHROMA_RECONSTRUCTION_EXPLICIT_FORCEABLE"},
+	{uint32(vk.FormatFeatureDisjointBit), "DISJOINT"},
+	{uint32(vk.FormatFeatureCositedChromaSamplesBit), "COSITED_CHROMA_SAMPLES"},
+	// Bits from extensions newer than the vulkan-go bindings.
+	{1 << 24, "FRAGMENT_DENSITY_MAP"},
+	{1 << 29, "ACCELERATION_STRUCTURE_VERTEX_BUFFER"},
+	{1 << 30, "FRAGMENT_SHADING_RATE_ATTACHMENT"},
+}
+
+func formatFeatureFlags(flags vk.FormatFeatureFlags) string {
+	return joinFlags(formatFeatureFlagNames(flags))
+}
+
+func formatFeatureFlagNames(flags vk.FormatFeatureFlags) []string {
+	return flagNames(uint32(flags), formatFeatureFlagTable)
+}
+
+// formatQueryUsage is the image usage PrintFormat checks image limits for.
+const formatQueryUsage = vk.ImageUsageFlags(vk.ImageUsageSampledBit | vk.ImageUsageColorAttachmentBit)
+
+// PrintFormat prints the features of format on the GPU at gpuIndex, and the
+// limits of 2D optimally tiled images of it used for sampling and as color
+// attachments.
+func PrintFormat(w io.Writer, v *VulkanDeviceInfo, gpuIndex int, format vk.Format) {
+	gpu := v.gpuDevices[gpuIndex]
+	properties := GetFormatProperties(gpu, format)
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Format %s", gpuIndex, FormatName(format)))
+	table.AddRow("Linear Tiling", formatFeatureFlags(properties.LinearTilingFeatures))
+	table.AddRow("Optimal Tiling", formatFeatureFlags(properties.OptimalTilingFeatures))
+	table.AddRow("Buffer", formatFeatureFlags(properties.BufferFeatures))
+
+	imageProperties, err := GetImageFormatProperties(gpu, format, vk.ImageType2d, vk.ImageTilingOptimal, formatQueryUsage, 0)
+	if err != nil {
+		table.AddRow("Sampled|Color Attachment Image", err)
+	} else {
+		extent := imageProperties.MaxExtent
+		table.AddRow("Max Extent", fmt.Sprintf("%dx%dx%d", extent.Width, extent.Height, extent.Depth))
+		table.AddRow("Max Mip Levels", imageProperties.MaxMipLevels)
+		table.AddRow("Max Array Layers", imageProperties.MaxArrayLayers)
+		table.AddRow("Sample Counts", sampleCountFlags(imageProperties.SampleCounts))
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
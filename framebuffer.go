@@ -0,0 +1,73 @@
+package vulkandevice
+
+import (
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// CreateFramebuffer creates a framebuffer of width x height pixels and
+// layers layers for renderPass, with attachments in the order of the
+// render pass attachments. Destroy it with DestroyFramebuffer before the
+// views and the render pass.
+func CreateFramebuffer(v *VulkanDeviceInfo, renderPass vk.RenderPass, attachments []vk.ImageView,
+	width, height, layers uint32) (vk.Framebuffer, error) {
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return vk.NullFramebuffer, ErrNoDevice
+	}
+	createInfo := &vk.FramebufferCreateInfo{
+		SType:           vk.StructureTypeFramebufferCreateInfo,
+		RenderPass:      renderPass,
+		AttachmentCount: uint32(len(attachments)),
+		PAttachments:    attachments,
+		Width:           width,
+		Height:          height,
+		Layers:          layers,
+	}
+	var framebuffer vk.Framebuffer
+	if err := vk.Error(vk.CreateFramebuffer(v.device, createInfo, v.allocator, &framebuffer)); err != nil {
+		err = fmt.Errorf("vkCreateFramebuffer failed with %s", err)
+		return vk.NullFramebuffer, err
+	}
+	return framebuffer, nil
+}
+
+// DestroyFramebuffer destroys fb. No command buffer using it may still be
+// pending.
+func DestroyFramebuffer(v *VulkanDeviceInfo, fb vk.Framebuffer) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil || fb == vk.NullFramebuffer {
+		return
+	}
+	vk.DestroyFramebuffer(v.device, fb, v.allocator)
+}
+
+// CreateFramebuffersForSwapchain creates one framebuffer for rp per image of
+// sc, in image order, the size of the swapchain. The attachments are the
+// color view of the image followed by depthView, unless it is
+// vk.NullImageView, as with CreateSimpleRenderPass. Recreate the
+// framebuffers along with the swapchain.
+func CreateFramebuffersForSwapchain(v *VulkanDeviceInfo, sc *VulkanSwapchainInfo, rp vk.RenderPass,
+	depthView vk.ImageView) ([]vk.Framebuffer, error) {
+
+	framebuffers := make([]vk.Framebuffer, 0, len(sc.ImageViews))
+	for _, view := range sc.ImageViews {
+		attachments := []vk.ImageView{view}
+		if depthView != vk.NullImageView {
+			attachments = append(attachments, depthView)
+		}
+		framebuffer, err := CreateFramebuffer(v, rp, attachments, sc.Extent.Width, sc.Extent.Height, 1)
+		if err != nil {
+			for _, fb := range framebuffers {
+				DestroyFramebuffer(v, fb)
+			}
+			return nil, err
+		}
+		framebuffers = append(framebuffers, framebuffer)
+	}
+	return framebuffers, nil
+}
@@ -3,6 +3,9 @@ module github.com/Buhrietoe/vulkandevice
 go 1.18
 
 require (
+	github.com/go-gl/glfw/v3.3/glfw v0.0.0-20221017161538-93cebf72946b
 	github.com/vulkan-go/vulkan v0.0.0-20210402152248-956e3850d8f9
 	github.com/xlab/tablewriter v0.0.0-20160610135559-80b567a11ad5
 )
+
+require gopkg.in/yaml.v3 v3.0.1
@@ -0,0 +1,37 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// physicalDeviceIDProperties has the C layout of
+// VkPhysicalDeviceIDProperties.
+type physicalDeviceIDProperties struct {
+	chainHeader
+	DeviceUUID      [vk.UuidSize]byte
+	DriverUUID      [vk.UuidSize]byte
+	DeviceLUID      [vk.LuidSize]byte
+	DeviceNodeMask  uint32
+	DeviceLUIDValid vk.Bool32
+}
+
+// getIDProperties queries VkPhysicalDeviceIDProperties for the GPU at
+// gpuIndex. ok is false if the instance or the GPU is Vulkan 1.0.
+func getIDProperties(v *VulkanDeviceInfo, gpuIndex int) (ids physicalDeviceIDProperties, ok bool) {
+	gpu := v.gpuDevices[gpuIndex]
+	if v.apiVersion < vk.MakeVersion(1, 1, 0) || getDeviceProperties(gpu).ApiVersion < vk.MakeVersion(1, 1, 0) {
+		return ids, false
+	}
+	ids.SType = vk.StructureTypePhysicalDeviceIdProperties
+	var chain structChain
+	chain.add(unsafe.Pointer(&ids), unsafe.Sizeof(ids))
+	return ids, queryProperties2(v, gpu, &chain)
+}
+
+// formatLUID renders a LUID as lowercase hex.
+func formatLUID(luid [vk.LuidSize]byte) string {
+	return fmt.Sprintf("%x", luid[:])
+}
@@ -0,0 +1,160 @@
+package vulkandevice
+
+import (
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// ImageInfo is a 2D image created by CreateImage together with the memory
+// bound to it.
+type ImageInfo struct {
+	Image       vk.Image
+	Memory      vk.DeviceMemory
+	Format      vk.Format
+	Extent      vk.Extent2D
+	MipLevels   uint32
+	ArrayLayers uint32
+}
+
+// ImageOption customizes the image created by CreateImage.
+type ImageOption func(*imageConfig)
+
+type imageConfig struct {
+	mipLevels   uint32
+	arrayLayers uint32
+}
+
+// WithMipLevels gives the image n mip levels instead of 1.
+func WithMipLevels(n uint32) ImageOption {
+	return func(c *imageConfig) {
+		c.mipLevels = n
+	}
+}
+
+// WithArrayLayers gives the image n array layers instead of 1.
+func WithArrayLayers(n uint32) ImageOption {
+	return func(c *imageConfig) {
+		c.arrayLayers = n
+	}
+}
+
+// CreateImage creates a single-sampled 2D image of width by height texels
+// for usage, allocates memory with memProps for it and binds it. The image
+// has 1 mip level and 1 array layer unless WithMipLevels or WithArrayLayers
+// is given, and starts in vk.ImageLayoutUndefined. Release it with
+// Destroy.
+func CreateImage(v *VulkanDeviceInfo, width, height uint32, format vk.Format, tiling vk.ImageTiling,
+	usage vk.ImageUsageFlags, memProps vk.MemoryPropertyFlags, opts ...ImageOption) (*ImageInfo, error) {
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return nil, ErrNoDevice
+	}
+	config := imageConfig{
+		mipLevels:   1,
+		arrayLayers: 1,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	createInfo := &vk.ImageCreateInfo{
+		SType:         vk.StructureTypeImageCreateInfo,
+		ImageType:     vk.ImageType2d,
+		Format:        format,
+		Extent:        vk.Extent3D{Width: width, Height: height, Depth: 1},
+		MipLevels:     config.mipLevels,
+		ArrayLayers:   config.arrayLayers,
+		Samples:       vk.SampleCount1Bit,
+		Tiling:        tiling,
+		Usage:         usage,
+		SharingMode:   vk.SharingModeExclusive,
+		InitialLayout: vk.ImageLayoutUndefined,
+	}
+	var image vk.Image
+	if err := vk.Error(vk.CreateImage(v.device, createInfo, v.allocator, &image)); err != nil {
+		err = fmt.Errorf("vkCreateImage failed with %s", err)
+		return nil, err
+	}
+
+	var requirements vk.MemoryRequirements
+	vk.GetImageMemoryRequirements(v.device, image, &requirements)
+	requirements.Deref()
+	memory, err := allocateMemory(v, requirements, memProps)
+	if err != nil {
+		vk.DestroyImage(v.device, image, v.allocator)
+		return nil, err
+	}
+	if err := vk.Error(vk.BindImageMemory(v.device, image, memory, 0)); err != nil {
+		vk.FreeMemory(v.device, memory, v.allocator)
+		vk.DestroyImage(v.device, image, v.allocator)
+		err = fmt.Errorf("vkBindImageMemory failed with %s", err)
+		return nil, err
+	}
+	return &ImageInfo{
+		Image:       image,
+		Memory:      memory,
+		Format:      format,
+		Extent:      vk.Extent2D{Width: width, Height: height},
+		MipLevels:   config.mipLevels,
+		ArrayLayers: config.arrayLayers,
+	}, nil
+}
+
+// CreateView creates a view of every mip level and array layer of the
+// image for aspect, a 2D array view if the image has several layers. The
+// caller destroys it with vk.DestroyImageView before destroying the image.
+func (i *ImageInfo) CreateView(v *VulkanDeviceInfo, aspect vk.ImageAspectFlags) (vk.ImageView, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return vk.NullImageView, ErrNoDevice
+	}
+	viewType := vk.ImageViewType2d
+	if i.ArrayLayers > 1 {
+		viewType = vk.ImageViewType2dArray
+	}
+	createInfo := &vk.ImageViewCreateInfo{
+		SType:    vk.StructureTypeImageViewCreateInfo,
+		Image:    i.Image,
+		ViewType: viewType,
+		Format:   i.Format,
+		Components: vk.ComponentMapping{
+			R: vk.ComponentSwizzleIdentity,
+			G: vk.ComponentSwizzleIdentity,
+			B: vk.ComponentSwizzleIdentity,
+			A: vk.ComponentSwizzleIdentity,
+		},
+		SubresourceRange: vk.ImageSubresourceRange{
+			AspectMask: aspect,
+			LevelCount: i.MipLevels,
+			LayerCount: i.ArrayLayers,
+		},
+	}
+	var view vk.ImageView
+	if err := vk.Error(vk.CreateImageView(v.device, createInfo, v.allocator, &view)); err != nil {
+		err = fmt.Errorf("vkCreateImageView failed with %s", err)
+		return vk.NullImageView, err
+	}
+	return view, nil
+}
+
+// Destroy destroys the image and frees its memory. Calling it again is a
+// no-op. The device must not be using the image, and its views must be
+// destroyed first.
+func (i *ImageInfo) Destroy(v *VulkanDeviceInfo) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if i == nil || v.device == nil {
+		return
+	}
+	if i.Image != vk.NullImage {
+		vk.DestroyImage(v.device, i.Image, v.allocator)
+		i.Image = vk.NullImage
+	}
+	if i.Memory != vk.NullDeviceMemory {
+		vk.FreeMemory(v.device, i.Memory, v.allocator)
+		i.Memory = vk.NullDeviceMemory
+	}
+}
@@ -0,0 +1,47 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// EnumerateInstanceExtensions returns the instance extensions provided by
+// the Vulkan implementation.
+func EnumerateInstanceExtensions() ([]vk.ExtensionProperties, error) {
+	var extensionCount uint32
+	err := vk.Error(vk.EnumerateInstanceExtensionProperties("", &extensionCount, nil))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateInstanceExtensionProperties failed with %s", err)
+		return nil, err
+	}
+	extensions := make([]vk.ExtensionProperties, extensionCount)
+	err = vk.Error(vk.EnumerateInstanceExtensionProperties("", &extensionCount, extensions))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateInstanceExtensionProperties failed with %s", err)
+		return nil, err
+	}
+	for i := range extensions {
+		extensions[i].Deref()
+	}
+	return extensions, nil
+}
+
+// PrintInstanceInfo prints the instance layers and instance extensions
+// available on the system.
+func PrintInstanceInfo(w io.Writer, v *VulkanDeviceInfo) {
+	layers := layersTable(v.instanceLayers)
+
+	extensions := tablewriter.CreateTable()
+	extensions.UTF8Box()
+	extensions.AddTitle("Instance Extensions")
+	extensions.AddHeaders("Extension", "Spec Version")
+	for _, extension := range sortExtensions(v.instanceExtensions) {
+		extensions.AddRow(vk.ToString(extension.ExtensionName[:]), extension.SpecVersion)
+	}
+
+	fmt.Fprintln(w, "\n"+layers.Render())
+	fmt.Fprintln(w, "\n"+extensions.Render())
+}
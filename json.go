@@ -0,0 +1,331 @@
+package vulkandevice
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// Version carries a Vulkan packed version both raw and decoded so
+// consumers don't need to reimplement vk.Version decoding.
+type Version struct {
+	Raw     uint32 `json:"raw"`
+	Version string `json:"version"`
+}
+
+func newVersion(v uint32) Version {
+	return Version{
+		Raw:     v,
+		Version: vk.Version(v).String(),
+	}
+}
+
+// newDriverVersion is newVersion for driver versions, which are
+// decoded with the vendor's encoding.
+func newDriverVersion(vendorID, v uint32) Version {
+	return Version{
+		Raw:     v,
+		Version: formatDriverVersion(vendorID, v),
+	}
+}
+
+// Report is the data gathered by GatherDeviceReport and printed by
+// PrintJSON. JSON field names are pinned with tags so they don't change with
+// the vulkan-go binding.
+type Report struct {
+	GPUCount            int            `json:"gpu_count"`
+	LoaderVersion       Version        `json:"loader_version"`
+	InstanceAPIVersion  Version        `json:"instance_api_version"`
+	PresentationEnabled bool           `json:"presentation_enabled"`
+	InstanceLayers      []Layer        `json:"instance_layers"`
+	InstanceExtensions  []Extension    `json:"instance_extensions"`
+	Devices             []DeviceReport `json:"devices"`
+
+	// SelectedGPU is the index of the GPU NewVulkanDevice chose.
+	SelectedGPU int `json:"selected_gpu"`
+}
+
+// DeviceReport describes a single physical device. It is gathered by
+// CollectReport and newDeviceReport and rendered by PrintInfo, PrintJSON
+// and DeviceInfoJSON.
+type DeviceReport struct {
+	Index             int             `json:"index"`
+	Name              string          `json:"name"`
+	VendorID          uint32          `json:"vendor_id"`
+	VendorName        string          `json:"vendor_name"`
+	DeviceID          uint32          `json:"device_id"`
+	DeviceType        string          `json:"device_type"`
+	APIVersion        Version         `json:"api_version"`
+	DriverVersion     Version         `json:"driver_version"`
+	PipelineCacheUUID string          `json:"pipeline_cache_uuid"`
+	DeviceUUID        string          `json:"device_uuid,omitempty"`
+	DriverUUID        string          `json:"driver_uuid,omitempty"`
+	DeviceLUID        string          `json:"device_luid,omitempty"`
+	DeviceNodeMask    uint32          `json:"device_node_mask,omitempty"`
+	PCIBusInfo        *PCIBusInfo     `json:"pci_bus_info,omitempty"`
+	Limits            Limits          `json:"limits"`
+	Features          map[string]bool `json:"features"`
+	QueueFamilies     []QueueFamily   `json:"queue_families"`
+	MemoryHeaps       []MemoryHeap    `json:"memory_heaps"`
+	MemoryTypes       []MemoryType    `json:"memory_types"`
+	Extensions        []Extension     `json:"extensions"`
+
+	// TextureCompression summarizes the block-compressed format families
+	// the GPU can sample from.
+	TextureCompression TextureCompression `json:"texture_compression"`
+
+	// The driver identification is only set when the GPU supports
+	// VK_KHR_driver_properties.
+	DriverID           string `json:"driver_id,omitempty"`
+	DriverName         string `json:"driver_name,omitempty"`
+	DriverInfo         string `json:"driver_info,omitempty"`
+	ConformanceVersion string `json:"conformance_version,omitempty"`
+
+	// PortabilitySubset is set for implementations layered over another
+	// API, such as MoltenVK, which advertise VK_KHR_portability_subset.
+	PortabilitySubset bool `json:"portability_subset"`
+
+	// Subgroup is only set for Vulkan 1.1 GPUs.
+	Subgroup *SubgroupInfo `json:"subgroup,omitempty"`
+}
+
+// Layer describes an instance layer.
+type Layer struct {
+	Name                  string  `json:"name"`
+	SpecVersion           Version `json:"spec_version"`
+	ImplementationVersion uint32  `json:"implementation_version"`
+	Description           string  `json:"description"`
+}
+
+// Extension describes an instance or device extension.
+type Extension struct {
+	Name        string `json:"name"`
+	SpecVersion uint32 `json:"spec_version"`
+}
+
+// PCIBusInfo is the PCI address of a GPU.
+type PCIBusInfo struct {
+	Domain   uint32 `json:"domain"`
+	Bus      uint32 `json:"bus"`
+	Device   uint32 `json:"device"`
+	Function uint32 `json:"function"`
+	Address  string `json:"address"`
+}
+
+// Extent3D is a width, height and depth in texels.
+type Extent3D struct {
+	Width  uint32 `json:"width"`
+	Height uint32 `json:"height"`
+	Depth  uint32 `json:"depth"`
+}
+
+// MemoryHeap describes a memory heap. Budget and Usage are nil unless the
+// GPU supports VK_EXT_memory_budget, so a usage of 0 bytes is kept.
+type MemoryHeap struct {
+	Index  uint32   `json:"index"`
+	Size   uint64   `json:"size"`
+	Budget *uint64  `json:"budget,omitempty"`
+	Usage  *uint64  `json:"usage,omitempty"`
+	Flags  []string `json:"flags"`
+}
+
+// MemoryType describes a memory type and the heap it allocates from.
+type MemoryType struct {
+	Index     uint32   `json:"index"`
+	HeapIndex uint32   `json:"heap_index"`
+	Flags     []string `json:"flags"`
+}
+
+// QueueFamily describes a queue family.
+type QueueFamily struct {
+	Index                       int      `json:"index"`
+	QueueCount                  uint32   `json:"queue_count"`
+	Flags                       []string `json:"flags"`
+	TimestampValidBits          uint32   `json:"timestamp_valid_bits"`
+	MinImageTransferGranularity Extent3D `json:"min_image_transfer_granularity"`
+}
+
+// GatherDeviceReport collects the instance information and the description
+// of every GPU enumerated by v.
+func GatherDeviceReport(v *VulkanDeviceInfo) (*Report, error) {
+	if v.instance == nil {
+		err := fmt.Errorf("GatherDeviceReport: device was destroyed")
+		return nil, err
+	}
+	report := &Report{
+		GPUCount:            len(v.gpuDevices),
+		LoaderVersion:       newVersion(v.loaderVersion),
+		InstanceAPIVersion:  newVersion(v.apiVersion),
+		PresentationEnabled: v.presentationEnabled,
+		InstanceLayers:      []Layer{},
+		InstanceExtensions:  []Extension{},
+		SelectedGPU:         v.gpuIndex,
+	}
+	for _, layer := range v.instanceLayers {
+		report.InstanceLayers = append(report.InstanceLayers, Layer{
+			Name:                  vk.ToString(layer.LayerName[:]),
+			SpecVersion:           newVersion(layer.SpecVersion),
+			ImplementationVersion: layer.ImplementationVersion,
+			Description:           vk.ToString(layer.Description[:]),
+		})
+	}
+	for _, extension := range sortExtensions(v.instanceExtensions) {
+		report.InstanceExtensions = append(report.InstanceExtensions, Extension{
+			Name:        vk.ToString(extension.ExtensionName[:]),
+			SpecVersion: extension.SpecVersion,
+		})
+	}
+	for i := range v.gpuDevices {
+		report.Devices = append(report.Devices, newDeviceReport(v, i))
+	}
+	return report, nil
+}
+
+// PrintJSON writes the GatherDeviceReport report to w as JSON.
+func PrintJSON(w io.Writer, v *VulkanDeviceInfo) error {
+	report, err := GatherDeviceReport(v)
+	if err != nil {
+		return err
+	}
+	out, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		err = fmt.Errorf("PrintJSON: %s", err)
+		return err
+	}
+	fmt.Fprintln(w, string(out))
+	return nil
+}
+
+// DeviceInfoJSON returns the JSON document describing the GPU at gpuIndex.
+func DeviceInfoJSON(v *VulkanDeviceInfo, gpuIndex int) ([]byte, error) {
+	if gpuIndex < 0 || gpuIndex >= len(v.gpuDevices) {
+		err := fmt.Errorf("DeviceInfoJSON: GPU index %d out of range", gpuIndex)
+		return nil, err
+	}
+	out, err := json.MarshalIndent(newDeviceReport(v, gpuIndex), "", "  ")
+	if err != nil {
+		err = fmt.Errorf("DeviceInfoJSON: %s", err)
+		return nil, err
+	}
+	return out, nil
+}
+
+// CollectReport describes gpu from its properties, features, queue
+// families, memory and extensions. The fields that need extensions of the
+// instance, such as the UUIDs, PCI address and driver identification, are
+// left empty; DeviceInfoJSON and GatherDeviceReport fill them in.
+func CollectReport(gpu vk.PhysicalDevice) (DeviceReport, error) {
+	gpuProperties := getDeviceProperties(gpu)
+	info := DeviceReport{
+		Name:              vk.ToString(gpuProperties.DeviceName[:]),
+		VendorID:          gpuProperties.VendorID,
+		VendorName:        VendorName(gpuProperties.VendorID),
+		DeviceID:          gpuProperties.DeviceID,
+		DeviceType:        DeviceTypeName(gpuProperties.DeviceType),
+		APIVersion:        newVersion(gpuProperties.ApiVersion),
+		DriverVersion:     newDriverVersion(gpuProperties.VendorID, gpuProperties.DriverVersion),
+		PipelineCacheUUID: formatUUID(gpuProperties.PipelineCacheUUID),
+		Limits:            newLimits(gpuProperties.Limits),
+		Features:          map[string]bool{},
+		QueueFamilies:     []QueueFamily{},
+		MemoryHeaps:       []MemoryHeap{},
+		MemoryTypes:       []MemoryType{},
+		Extensions:        []Extension{},
+	}
+	features := GetDeviceFeatures(gpu)
+	for _, f := range deviceFeatures {
+		info.Features[f.name] = f.value(&features).B()
+	}
+	for i, family := range GetQueueFamilyProperties(gpu) {
+		granularity := family.MinImageTransferGranularity
+		info.QueueFamilies = append(info.QueueFamilies, QueueFamily{
+			Index:              i,
+			QueueCount:         family.QueueCount,
+			Flags:              queueFlagNames(family.QueueFlags),
+			TimestampValidBits: family.TimestampValidBits,
+			MinImageTransferGranularity: Extent3D{
+				Width:  granularity.Width,
+				Height: granularity.Height,
+				Depth:  granularity.Depth,
+			},
+		})
+	}
+	memoryProperties := GetMemoryProperties(gpu)
+	for i := uint32(0); i < memoryProperties.MemoryHeapCount; i++ {
+		heap := memoryProperties.MemoryHeaps[i]
+		info.MemoryHeaps = append(info.MemoryHeaps, MemoryHeap{
+			Index: i,
+			Size:  uint64(heap.Size),
+			Flags: memoryHeapFlagNames(heap.Flags),
+		})
+	}
+	for i := uint32(0); i < memoryProperties.MemoryTypeCount; i++ {
+		memoryType := memoryProperties.MemoryTypes[i]
+		info.MemoryTypes = append(info.MemoryTypes, MemoryType{
+			Index:     i,
+			HeapIndex: memoryType.HeapIndex,
+			Flags:     memoryPropertyFlagNames(memoryType.PropertyFlags),
+		})
+	}
+	extensions, err := EnumerateDeviceExtensions(gpu)
+	if err != nil {
+		return info, err
+	}
+	for _, extension := range sortExtensions(extensions) {
+		info.Extensions = append(info.Extensions, Extension{
+			Name:        vk.ToString(extension.ExtensionName[:]),
+			SpecVersion: extension.SpecVersion,
+		})
+	}
+	info.PortabilitySubset = hasExtension(extensions, portabilitySubsetExtensionName)
+	return info, nil
+}
+
+// newDeviceReport is CollectReport for the GPU at gpuIndex, completed with
+// the fields that need v's instance. Extensions are left empty if they
+// can't be enumerated.
+func newDeviceReport(v *VulkanDeviceInfo, gpuIndex int) DeviceReport {
+	info, _ := CollectReport(v.gpuDevices[gpuIndex])
+	info.Index = gpuIndex
+	if ids, ok := getIDProperties(v, gpuIndex); ok {
+		info.DeviceUUID = formatUUID(ids.DeviceUUID)
+		info.DriverUUID = formatUUID(ids.DriverUUID)
+		if ids.DeviceLUIDValid.B() {
+			info.DeviceLUID = formatLUID(ids.DeviceLUID)
+		}
+		info.DeviceNodeMask = ids.DeviceNodeMask
+	}
+	if pci, ok := getPCIBusInfo(v, gpuIndex); ok {
+		info.PCIBusInfo = &PCIBusInfo{
+			Domain:   pci.PCIDomain,
+			Bus:      pci.PCIBus,
+			Device:   pci.PCIDevice,
+			Function: pci.PCIFunction,
+			Address:  formatPCIAddress(pci),
+		}
+	}
+	if driver, err := GetDriverProperties(v, gpuIndex); err == nil {
+		info.DriverID = driver.DriverIDName
+		info.DriverName = driver.DriverName
+		info.DriverInfo = driver.DriverInfo
+		info.ConformanceVersion = driver.ConformanceVersion
+	}
+	if budget, ok := getMemoryBudget(v, gpuIndex); ok {
+		for i := range info.MemoryHeaps {
+			info.MemoryHeaps[i].Budget = &budget.HeapBudget[i]
+			info.MemoryHeaps[i].Usage = &budget.HeapUsage[i]
+		}
+	}
+	if subgroup, err := GetSubgroupProperties(v, gpuIndex); err == nil {
+		info.Subgroup = &subgroup
+	}
+	info.TextureCompression = GetTextureCompression(v, gpuIndex)
+	return info
+}
+
+// formatUUID renders a UUID as lowercase hyphenated hex.
+func formatUUID(uuid [16]byte) string {
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16])
+}
@@ -0,0 +1,226 @@
+package vulkandevice
+
+import (
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// Limits mirrors vk.PhysicalDeviceLimits with plain numeric types.
+type Limits struct {
+	MaxImageDimension1D                             uint32     `json:"max_image_dimension_1d"`
+	MaxImageDimension2D                             uint32     `json:"max_image_dimension_2d"`
+	MaxImageDimension3D                             uint32     `json:"max_image_dimension_3d"`
+	MaxImageDimensionCube                           uint32     `json:"max_image_dimension_cube"`
+	MaxImageArrayLayers                             uint32     `json:"max_image_array_layers"`
+	MaxTexelBufferElements                          uint32     `json:"max_texel_buffer_elements"`
+	MaxUniformBufferRange                           uint32     `json:"max_uniform_buffer_range"`
+	MaxStorageBufferRange                           uint32     `json:"max_storage_buffer_range"`
+	MaxPushConstantsSize                            uint32     `json:"max_push_constants_size"`
+	MaxMemoryAllocationCount                        uint32     `json:"max_memory_allocation_count"`
+	MaxSamplerAllocationCount                       uint32     `json:"max_sampler_allocation_count"`
+	BufferImageGranularity                          uint64     `json:"buffer_image_granularity"`
+	SparseAddressSpaceSize                          uint64     `json:"sparse_address_space_size"`
+	MaxBoundDescriptorSets                          uint32     `json:"max_bound_descriptor_sets"`
+	MaxPerStageDescriptorSamplers                   uint32     `json:"max_per_stage_descriptor_samplers"`
+	MaxPerStageDescriptorUniformBuffers             uint32     `json:"max_per_stage_descriptor_uniform_buffers"`
+	MaxPerStageDescriptorStorageBuffers             uint32     `json:"max_per_stage_descriptor_storage_buffers"`
+	MaxPerStageDescriptorSampledImages              uint32     `json:"max_per_stage_descriptor_sampled_images"`
+	MaxPerStageDescriptorStorageImages              uint32     `json:"max_per_stage_descriptor_storage_images"`
+	MaxPerStageDescriptorInputAttachments           uint32     `json:"max_per_stage_descriptor_input_attachments"`
+	MaxPerStageResources                            uint32     `json:"max_per_stage_resources"`
+	MaxDescriptorSetSamplers                        uint32     `json:"max_descriptor_set_samplers"`
+	MaxDescriptorSetUniformBuffers                  uint32     `json:"max_descriptor_set_uniform_buffers"`
+	MaxDescriptorSetUniformBuffersDynamic           uint32     `json:"max_descriptor_set_uniform_buffers_dynamic"`
+	MaxDescriptorSetStorageBuffers                  uint32     `json:"max_descriptor_set_storage_buffers"`
+	MaxDescriptorSetStorageBuffersDynamic           uint32     `json:"max_descriptor_set_storage_buffers_dynamic"`
+	MaxDescriptorSetSampledImages                   uint32     `json:"max_descriptor_set_sampled_images"`
+	MaxDescriptorSetStorageImages                   uint32     `json:"max_descriptor_set_storage_images"`
+	MaxDescriptorSetInputAttachments                uint32     `json:"max_descriptor_set_input_attachments"`
+	MaxVertexInputAttributes                        uint32     `json:"max_vertex_input_attributes"`
+	MaxVertexInputBindings                          uint32     `json:"max_vertex_input_bindings"`
+	MaxVertexInputAttributeOffset                   uint32     `json:"max_vertex_input_attribute_offset"`
+	MaxVertexInputBindingStride                     uint32     `json:"max_vertex_input_binding_stride"`
+	MaxVertexOutputComponents                       uint32     `json:"max_vertex_output_components"`
+	MaxTessellationGenerationLevel                  uint32     `json:"max_tessellation_generation_level"`
+	MaxTessellationPatchSize                        uint32     `json:"max_tessellation_patch_size"`
+	MaxTessellationControlPerVertexInputComponents  uint32     `json:"max_tessellation_control_per_vertex_input_components"`
+	MaxTessellationControlPerVertexOutputComponents uint32     `json:"max_tessellation_control_per_vertex_output_components"`
+	MaxTessellationControlPerPatchOutputComponents  uint32     `json:"max_tessellation_control_per_patch_output_components"`
+	MaxTessellationControlTotalOutputComponents     uint32     `json:"max_tessellation_control_total_output_components"`
+	MaxTessellationEvaluationInputComponents        uint32     `json:"max_tessellation_evaluation_input_components"`
+	MaxTessellationEvaluationOutputComponents       uint32     `json:"max_tessellation_evaluation_output_components"`
+	MaxGeometryShaderInvocations                    uint32     `json:"max_geometry_shader_invocations"`
+	MaxGeometryInputComponents                      uint32     `json:"max_geometry_input_components"`
+	MaxGeometryOutputComponents                     uint32     `json:"max_geometry_output_components"`
+	MaxGeometryOutputVertices                       uint32     `json:"max_geometry_output_vertices"`
+	MaxGeometryTotalOutputComponents                uint32     `json:"max_geometry_total_output_components"`
+	MaxFragmentInputComponents                      uint32     `json:"max_fragment_input_components"`
+	MaxFragmentOutputAttachments                    uint32     `json:"max_fragment_output_attachments"`
+	MaxFragmentDualSrcAttachments                   uint32     `json:"max_fragment_dual_src_attachments"`
+	MaxFragmentCombinedOutputResources              uint32     `json:"max_fragment_combined_output_resources"`
+	MaxComputeSharedMemorySize                      uint32     `json:"max_compute_shared_memory_size"`
+	MaxComputeWorkGroupCount                        [3]uint32  `json:"max_compute_work_group_count"`
+	MaxComputeWorkGroupInvocations                  uint32     `json:"max_compute_work_group_invocations"`
+	MaxComputeWorkGroupSize                         [3]uint32  `json:"max_compute_work_group_size"`
+	SubPixelPrecisionBits                           uint32     `json:"sub_pixel_precision_bits"`
+	SubTexelPrecisionBits                           uint32     `json:"sub_texel_precision_bits"`
+	MipmapPrecisionBits                             uint32     `json:"mipmap_precision_bits"`
+	MaxDrawIndexedIndexValue                        uint32     `json:"max_draw_indexed_index_value"`
+	MaxDrawIndirectCount                            uint32     `json:"max_draw_indirect_count"`
+	MaxSamplerLodBias                               float32    `json:"max_sampler_lod_bias"`
+	MaxSamplerAnisotropy                            float32    `json:"max_sampler_anisotropy"`
+	MaxViewports                                    uint32     `json:"max_viewports"`
+	MaxViewportDimensions                           [2]uint32  `json:"max_viewport_dimensions"`
+	ViewportBoundsRange                             [2]float32 `json:"viewport_bounds_range"`
+	ViewportSubPixelBits                            uint32     `json:"viewport_sub_pixel_bits"`
+	MinMemoryMapAlignment                           uint64     `json:"min_memory_map_alignment"`
+	MinTexelBufferOffsetAlignment                   uint64     `json:"min_texel_buffer_offset_alignment"`
+	MinUniformBufferOffsetAlignment                 uint64     `json:"min_uniform_buffer_offset_alignment"`
+	MinStorageBufferOffsetAlignment                 uint64     `json:"min_storage_buffer_offset_alignment"`
+	MinTexelOffset                                  int32      `json:"min_texel_offset"`
+	MaxTexelOffset                                  uint32     `json:"max_texel_offset"`
+	MinTexelGatherOffset                            int32      `json:"min_texel_gather_offset"`
+	MaxTexelGatherOffset                            uint32     `json:"max_texel_gather_offset"`
+	MinInterpolationOffset                          float32    `json:"min_interpolation_offset"`
+	MaxInterpolationOffset                          float32    `json:"max_interpolation_offset"`
+	SubPixelInterpolationOffsetBits                 uint32     `json:"sub_pixel_interpolation_offset_bits"`
+	MaxFramebufferWidth                             uint32     `json:"max_framebuffer_width"`
+	MaxFramebufferHeight                            uint32     `json:"max_framebuffer_height"`
+	MaxFramebufferLayers                            uint32     `json:"max_framebuffer_layers"`
+	FramebufferColorSampleCounts                    uint32     `json:"framebuffer_color_sample_counts"`
+	FramebufferDepthSampleCounts                    uint32     `json:"framebuffer_depth_sample_counts"`
+	FramebufferStencilSampleCounts                  uint32     `json:"framebuffer_stencil_sample_counts"`
+	FramebufferNoAttachmentsSampleCounts            uint32     `json:"framebuffer_no_attachments_sample_counts"`
+	MaxColorAttachments                             uint32     `json:"max_color_attachments"`
+	SampledImageColorSampleCounts                   uint32     `json:"sampled_image_color_sample_counts"`
+	SampledImageIntegerSampleCounts                 uint32     `json:"sampled_image_integer_sample_counts"`
+	SampledImageDepthSampleCounts                   uint32     `json:"sampled_image_depth_sample_counts"`
+	SampledImageStencilSampleCounts                 uint32     `json:"sampled_image_stencil_sample_counts"`
+	StorageImageSampleCounts                        uint32     `json:"storage_image_sample_counts"`
+	MaxSampleMaskWords                              uint32     `json:"max_sample_mask_words"`
+	TimestampComputeAndGraphics                     bool       `json:"timestamp_compute_and_graphics"`
+	TimestampPeriod                                 float32    `json:"timestamp_period"`
+	MaxClipDistances                                uint32     `json:"max_clip_distances"`
+	MaxCullDistances                                uint32     `json:"max_cull_distances"`
+	MaxCombinedClipAndCullDistances                 uint32     `json:"max_combined_clip_and_cull_distances"`
+	DiscreteQueuePriorities                         uint32     `json:"discrete_queue_priorities"`
+	PointSizeRange                                  [2]float32 `json:"point_size_range"`
+	LineWidthRange                                  [2]float32 `json:"line_width_range"`
+	PointSizeGranularity                            float32    `json:"point_size_granularity"`
+	LineWidthGranularity                            float32    `json:"line_width_granularity"`
+	StrictLines                                     bool       `json:"strict_lines"`
+	StandardSampleLocations                         bool       `json:"standard_sample_locations"`
+	OptimalBufferCopyOffsetAlignment                uint64     `json:"optimal_buffer_copy_offset_alignment"`
+	OptimalBufferCopyRowPitchAlignment              uint64     `json:"optimal_buffer_copy_row_pitch_alignment"`
+	NonCoherentAtomSize                             uint64     `json:"non_coherent_atom_size"`
+}
+
+func newLimits(limits vk.PhysicalDeviceLimits) Limits {
+	return Limits{
+		MaxImageDimension1D:                             limits.MaxImageDimension1D,
+		MaxImageDimension2D:                             limits.MaxImageDimension2D,
+		MaxImageDimension3D:                             limits.MaxImageDimension3D,
+		MaxImageDimensionCube:                           limits.MaxImageDimensionCube,
+		MaxImageArrayLayers:                             limits.MaxImageArrayLayers,
+		MaxTexelBufferElements:                          limits.MaxTexelBufferElements,
+		MaxUniformBufferRange:                           limits.MaxUniformBufferRange,
+		MaxStorageBufferRange:                           limits.MaxStorageBufferRange,
+		MaxPushConstantsSize:                            limits.MaxPushConstantsSize,
+		MaxMemoryAllocationCount:                        limits.MaxMemoryAllocationCount,
+		MaxSamplerAllocationCount:                       limits.MaxSamplerAllocationCount,
+		BufferImageGranularity:                          uint64(limits.BufferImageGranularity),
+		SparseAddressSpaceSize:                          uint64(limits.SparseAddressSpaceSize),
+		MaxBoundDescriptorSets:                          limits.MaxBoundDescriptorSets,
+		MaxPerStageDescriptorSamplers:                   limits.MaxPerStageDescriptorSamplers,
+		MaxPerStageDescriptorUniformBuffers:             limits.MaxPerStageDescriptorUniformBuffers,
+		MaxPerStageDescriptorStorageBuffers:             limits.MaxPerStageDescriptorStorageBuffers,
+		MaxPerStageDescriptorSampledImages:              limits.MaxPerStageDescriptorSampledImages,
+		MaxPerStageDescriptorStorageImages:              limits.MaxPerStageDescriptorStorageImages,
+		MaxPerStageDescriptorInputAttachments:           limits.MaxPerStageDescriptorInputAttachments,
+		MaxPerStageResources:                            limits.MaxPerStageResources,
+		MaxDescriptorSetSamplers:                        limits.MaxDescriptorSetSamplers,
+		MaxDescriptorSetUniformBuffers:                  limits.MaxDescriptorSetUniformBuffers,
+		MaxDescriptorSetUniformBuffersDynamic:           limits.MaxDescriptorSetUniformBuffersDynamic,
+		MaxDescriptorSetStorageBuffers:                  limits.MaxDescriptorSetStorageBuffers,
+		MaxDescriptorSetStorageBuffersDynamic:           limits.MaxDescriptorSetStorageBuffersDynamic,
+		MaxDescriptorSetSampledImages:                   limits.MaxDescriptorSetSampledImages,
+		MaxDescriptorSetStorageImages:                   limits.MaxDescriptorSetStorageImages,
+		MaxDescriptorSetInputAttachments:                limits.MaxDescriptorSetInputAttachments,
+		MaxVertexInputAttributes:                        limits.MaxVertexInputAttributes,
+		MaxVertexInputBindings:                          limits.MaxVertexInputBindings,
+		MaxVertexInputAttributeOffset:                   limits.MaxVertexInputAttributeOffset,
+		MaxVertexInputBindingStride:                     limits.MaxVertexInputBindingStride,
+		MaxVertexOutputComponents:                       limits.MaxVertexOutputComponents,
+		MaxTessellationGenerationLevel:                  limits.MaxTessellationGenerationLevel,
+		MaxTessellationPatchSize:                        limits.MaxTessellationPatchSize,
+		MaxTessellationControlPerVertexInputComponents:  limits.MaxTessellationControlPerVertexInputComponents,
+		MaxTessellationControlPerVertexOutputComponents: limits.MaxTessellationControlPerVertexOutputComponents,
+		MaxTessellationControlPerPatchOutputComponents:  limits.MaxTessellationControlPerPatchOutputComponents,
+		MaxTessellationControlTotalOutputComponents:     limits.MaxTessellationControlTotalOutputComponents,
+		MaxTessellationEvaluationInputComponents:        limits.MaxTessellationEvaluationInputComponents,
+		MaxTessellationEvaluationOutputComponents:       limits.MaxTessellationEvaluationOutputComponents,
+		MaxGeometryShaderInvocations:                    limits.MaxGeometryShaderInvocations,
+		MaxGeometryInputComponents:                      limits.MaxGeometryInputComponents,
+		MaxGeometryOutputComponents:                     limits.MaxGeometryOutputComponents,
+		MaxGeometryOutputVertices:                       limits.MaxGeometryOutputVertices,
+		MaxGeometryTotalOutputComponents:                limits.MaxGeometryTotalOutputComponents,
+		MaxFragmentInputComponents:                      limits.MaxFragmentInputComponents,
+		MaxFragmentOutputAttachments:                    limits.MaxFragmentOutputAttachments,
+		MaxFragmentDualSrcAttachments:                   limits.MaxFragmentDualSrcAttachments,
+		MaxFragmentCombinedOutputResources:              limits.MaxFragmentCombinedOutputResources,
+		MaxComputeSharedMemorySize:                      limits.MaxComputeSharedMemorySize,
+		MaxComputeWorkGroupCount:                        limits.MaxComputeWorkGroupCount,
+		MaxComputeWorkGroupInvocations:                  limits.MaxComputeWorkGroupInvocations,
+		MaxComputeWorkGroupSize:                         limits.MaxComputeWorkGroupSize,
+		SubPixelPrecisionBits:                           limits.SubPixelPrecisionBits,
+		SubTexelPrecisionBits:                           limits.SubTexelPrecisionBits,
+		MipmapPrecisionBits:                             limits.MipmapPrecisionBits,
+		MaxDrawIndexedIndexValue:                        limits.MaxDrawIndexedIndexValue,
+		MaxDrawIndirectCount:                            limits.MaxDrawIndirectCount,
+		MaxSamplerLodBias:                               limits.MaxSamplerLodBias,
+		MaxSamplerAnisotropy:                            limits.MaxSamplerAnisotropy,
+		MaxViewports:                                    limits.MaxViewports,
+		MaxViewportDimensions:                           limits.MaxViewportDimensions,
+		ViewportBoundsRange:                             limits.ViewportBoundsRange,
+		ViewportSubPixelBits:                            limits.ViewportSubPixelBits,
+		MinMemoryMapAlignment:                           uint64(limits.MinMemoryMapAlignment),
+		MinTexelBufferOffsetAlignment:                   uint64(limits.MinTexelBufferOffsetAlignment),
+		MinUniformBufferOffsetAlignment:                 uint64(limits.MinUniformBufferOffsetAlignment),
+		MinStorageBufferOffsetAlignment:                 uint64(limits.MinStorageBufferOffsetAlignment),
+		MinTexelOffset:                                  limits.MinTexelOffset,
+		MaxTexelOffset:                                  limits.MaxTexelOffset,
+		MinTexelGatherOffset:                            limits.MinTexelGatherOffset,
+		MaxTexelGatherOffset:                            limits.MaxTexelGatherOffset,
+		MinInterpolationOffset:                          limits.MinInterpolationOffset,
+		MaxInterpolationOffset:                          limits.MaxInterpolationOffset,
+		SubPixelInterpolationOffsetBits:                 limits.SubPixelInterpolationOffsetBits,
+		MaxFramebufferWidth:                             limits.MaxFramebufferWidth,
+		MaxFramebufferHeight:                            limits.MaxFramebufferHeight,
+		MaxFramebufferLayers:                            limits.MaxFramebufferLayers,
+		FramebufferColorSampleCounts:                    uint32(limits.FramebufferColorSampleCounts),
+		FramebufferDepthSampleCounts:                    uint32(limits.FramebufferDepthSampleCounts),
+		FramebufferStencilSampleCounts:                  uint32(limits.FramebufferStencilSampleCounts),
+		FramebufferNoAttachmentsSampleCounts:            uint32(limits.FramebufferNoAttachmentsSampleCounts),
+		MaxColorAttachments:                             limits.MaxColorAttachments,
+		SampledImageColorSampleCounts:                   uint32(limits.SampledImageColorSampleCounts),
+		SampledImageIntegerSampleCounts:                 uint32(limits.SampledImageIntegerSampleCounts),
+		SampledImageDepthSampleCounts:                   uint32(limits.SampledImageDepthSampleCounts),
+		SampledImageStencilSampleCounts:                 uint32(limits.SampledImageStencilSampleCounts),
+		StorageImageSampleCounts:                        uint32(limits.StorageImageSampleCounts),
+		MaxSampleMaskWords:                              limits.MaxSampleMaskWords,
+		TimestampComputeAndGraphics:                     limits.TimestampComputeAndGraphics.B(),
+		TimestampPeriod:                                 limits.TimestampPeriod,
+		MaxClipDistances:                                limits.MaxClipDistances,
+		MaxCullDistances:                                limits.MaxCullDistances,
+		MaxCombinedClipAndCullDistances:                 limits.MaxCombinedClipAndCullDistances,
+		DiscreteQueuePriorities:                         limits.DiscreteQueuePriorities,
+		PointSizeRange:                                  limits.PointSizeRange,
+		LineWidthRange:                                  limits.LineWidthRange,
+		PointSizeGranularity:                            limits.PointSizeGranularity,
+		LineWidthGranularity:                            limits.LineWidthGranularity,
+		StrictLines:                                     limits.StrictLines.B(),
+		StandardSampleLocations:                         limits.StandardSampleLocations.B(),
+		OptimalBufferCopyOffsetAlignment:                uint64(limits.OptimalBufferCopyOffsetAlignment),
+		OptimalBufferCopyRowPitchAlignment:              uint64(limits.OptimalBufferCopyRowPitchAlignment),
+		NonCoherentAtomSize:                             uint64(limits.NonCoherentAtomSize),
+	}
+}
@@ -0,0 +1,83 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// ErrNoLayersAvailable is returned by EnumerateLayers when no instance layers
+// are installed.
+var ErrNoLayersAvailable = errors.New("no Vulkan instance layers available")
+
+// ErrLayerNotAvailable is returned when a layer requested with WithLayers
+// isn't installed. The error lists the missing layers.
+var ErrLayerNotAvailable = errors.New("layer not available")
+
+// EnumerateLayers returns the instance layers installed on the system.
+func EnumerateLayers() ([]vk.LayerProperties, error) {
+	var layerCount uint32
+	err := vk.Error(vk.EnumerateInstanceLayerProperties(&layerCount, nil))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateInstanceLayerProperties failed with %s", err)
+		return nil, err
+	}
+	if layerCount == 0 {
+		return nil, ErrNoLayersAvailable
+	}
+	layers := make([]vk.LayerProperties, layerCount)
+	err = vk.Error(vk.EnumerateInstanceLayerProperties(&layerCount, layers))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateInstanceLayerProperties failed with %s", err)
+		return nil, err
+	}
+	for i := range layers {
+		layers[i].Deref()
+	}
+	return layers, nil
+}
+
+// PrintLayers prints the instance layers installed on the system. It does
+// not need a VulkanDeviceInfo, only an initialized loader.
+func PrintLayers(w io.Writer) {
+	layers, err := EnumerateLayers()
+	if err != nil && !errors.Is(err, ErrNoLayersAvailable) {
+		table := tablewriter.CreateTable()
+		table.UTF8Box()
+		table.AddTitle("Instance Layers")
+		table.AddRow("Error", err.Error())
+		fmt.Fprintln(w, "\n"+table.Render())
+		return
+	}
+	fmt.Fprintln(w, "\n"+layersTable(layers).Render())
+}
+
+func layersTable(layers []vk.LayerProperties) *tablewriter.Table {
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle("Instance Layers")
+	table.AddHeaders("Layer", "Spec Version", "Implementation Version", "Description")
+	for _, layer := range layers {
+		table.AddRow(vk.ToString(layer.LayerName[:]), vk.Version(layer.SpecVersion),
+			layer.ImplementationVersion, vk.ToString(layer.Description[:]))
+	}
+	if len(layers) == 0 {
+		table.AddRow("None", "", "", "")
+	}
+	return table
+}
+
+// hasLayer reports whether name, with or without a NUL terminator, is
+// among layers.
+func hasLayer(layers []vk.LayerProperties, name string) bool {
+	name = vk.ToString([]byte(name))
+	for _, layer := range layers {
+		if vk.ToString(layer.LayerName[:]) == name {
+			return true
+		}
+	}
+	return false
+}
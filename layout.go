@@ -0,0 +1,124 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// ErrUnknownLayoutTransition is returned by TransitionImageLayout for a
+// layout pair it has no barrier for, when no PipelineBarrierParams are
+// given.
+var ErrUnknownLayoutTransition = errors.New("unknown image layout transition")
+
+// PipelineBarrierParams are the access and stage masks of an image layout
+// transition barrier.
+type PipelineBarrierParams struct {
+	SrcAccessMask vk.AccessFlags
+	DstAccessMask vk.AccessFlags
+	SrcStageMask  vk.PipelineStageFlags
+	DstStageMask  vk.PipelineStageFlags
+}
+
+type layoutTransition struct {
+	oldLayout vk.ImageLayout
+	newLayout vk.ImageLayout
+}
+
+// layoutTransitions are the barriers of well-known layout transitions:
+// uploads, mipmap generation, render targets and presentation.
+var layoutTransitions = map[layoutTransition]PipelineBarrierParams{
+	{vk.ImageLayoutUndefined, vk.ImageLayoutTransferDstOptimal}: {
+		DstAccessMask: vk.AccessFlags(vk.AccessTransferWriteBit),
+		SrcStageMask:  vk.PipelineStageFlags(vk.PipelineStageTopOfPipeBit),
+		DstStageMask:  vk.PipelineStageFlags(vk.PipelineStageTransferBit),
+	},
+	{vk.ImageLayoutUndefined, vk.ImageLayoutGeneral}: {
+		DstAccessMask: vk.AccessFlags(vk.AccessShaderReadBit | vk.AccessShaderWriteBit),
+		SrcStageMask:  vk.PipelineStageFlags(vk.PipelineStageTopOfPipeBit),
+		DstStageMask:  vk.PipelineStageFlags(vk.PipelineStageComputeShaderBit),
+	},
+	{vk.ImageLayoutUndefined, vk.ImageLayoutColorAttachmentOptimal}: {
+		DstAccessMask: vk.AccessFlags(vk.AccessColorAttachmentReadBit | vk.AccessColorAttachmentWriteBit),
+		SrcStageMask:  vk.PipelineStageFlags(vk.PipelineStageTopOfPipeBit),
+		DstStageMask:  vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit),
+	},
+	{vk.ImageLayoutUndefined, vk.ImageLayoutDepthStencilAttachmentOptimal}: {
+		DstAccessMask: vk.AccessFlags(vk.AccessDepthStencilAttachmentReadBit | vk.AccessDepthStencilAttachmentWriteBit),
+		SrcStageMask:  vk.PipelineStageFlags(vk.PipelineStageTopOfPipeBit),
+		DstStageMask:  vk.PipelineStageFlags(vk.PipelineStageEarlyFragmentTestsBit),
+	},
+	{vk.ImageLayoutTransferDstOptimal, vk.ImageLayoutShaderReadOnlyOptimal}: {
+		SrcAccessMask: vk.AccessFlags(vk.AccessTransferWriteBit),
+		DstAccessMask: vk.AccessFlags(vk.AccessShaderReadBit),
+		SrcStageMask:  vk.PipelineStageFlags(vk.PipelineStageTransferBit),
+		DstStageMask:  vk.PipelineStageFlags(vk.PipelineStageFragmentShaderBit),
+	},
+	{vk.ImageLayoutTransferDstOptimal, vk.ImageLayoutTransferSrcOptimal}: {
+		SrcAccessMask: vk.AccessFlags(vk.AccessTransferWriteBit),
+		DstAccessMask: vk.AccessFlags(vk.AccessTransferReadBit),
+		SrcStageMask:  vk.PipelineStageFlags(vk.PipelineStageTransferBit),
+		DstStageMask:  vk.PipelineStageFlags(vk.PipelineStageTransferBit),
+	},
+	{vk.ImageLayoutTransferSrcOptimal, vk.ImageLayoutShaderReadOnlyOptimal}: {
+		SrcAccessMask: vk.AccessFlags(vk.AccessTransferReadBit),
+		DstAccessMask: vk.AccessFlags(vk.AccessShaderReadBit),
+		SrcStageMask:  vk.PipelineStageFlags(vk.PipelineStageTransferBit),
+		DstStageMask:  vk.PipelineStageFlags(vk.PipelineStageFragmentShaderBit),
+	},
+	{vk.ImageLayoutShaderReadOnlyOptimal, vk.ImageLayoutTransferDstOptimal}: {
+		SrcAccessMask: vk.AccessFlags(vk.AccessShaderReadBit),
+		DstAccessMask: vk.AccessFlags(vk.AccessTransferWriteBit),
+		SrcStageMask:  vk.PipelineStageFlags(vk.PipelineStageFragmentShaderBit),
+		DstStageMask:  vk.PipelineStageFlags(vk.PipelineStageTransferBit),
+	},
+	{vk.ImageLayoutColorAttachmentOptimal, vk.ImageLayoutShaderReadOnlyOptimal}: {
+		SrcAccessMask: vk.AccessFlags(vk.AccessColorAttachmentWriteBit),
+		DstAccessMask: vk.AccessFlags(vk.AccessShaderReadBit),
+		SrcStageMask:  vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit),
+		DstStageMask:  vk.PipelineStageFlags(vk.PipelineStageFragmentShaderBit),
+	},
+	{vk.ImageLayoutColorAttachmentOptimal, vk.ImageLayoutPresentSrc}: {
+		SrcAccessMask: vk.AccessFlags(vk.AccessColorAttachmentWriteBit),
+		SrcStageMask:  vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit),
+		DstStageMask:  vk.PipelineStageFlags(vk.PipelineStageBottomOfPipeBit),
+	},
+}
+
+// TransitionImageLayout records a pipeline barrier into cmd moving every mip
+// level and array layer of image from oldLayout to newLayout. The access
+// and stage masks come from a table of well-known transitions, such as
+// Undefined to TransferDstOptimal and TransferDstOptimal to
+// ShaderReadOnlyOptimal. For other pairs the first fallback is used, and
+// without one ErrUnknownLayoutTransition is returned and nothing is
+// recorded.
+func TransitionImageLayout(cmd vk.CommandBuffer, image vk.Image, oldLayout, newLayout vk.ImageLayout,
+	aspect vk.ImageAspectFlags, fallback ...PipelineBarrierParams) error {
+
+	params, ok := layoutTransitions[layoutTransition{oldLayout, newLayout}]
+	if !ok {
+		if len(fallback) == 0 {
+			err := fmt.Errorf("%w: %d to %d", ErrUnknownLayoutTransition, oldLayout, newLayout)
+			return err
+		}
+		params = fallback[0]
+	}
+	barrier := []vk.ImageMemoryBarrier{{
+		SType:               vk.StructureTypeImageMemoryBarrier,
+		SrcAccessMask:       params.SrcAccessMask,
+		DstAccessMask:       params.DstAccessMask,
+		OldLayout:           oldLayout,
+		NewLayout:           newLayout,
+		SrcQueueFamilyIndex: vk.QueueFamilyIgnored,
+		DstQueueFamilyIndex: vk.QueueFamilyIgnored,
+		Image:               image,
+		SubresourceRange: vk.ImageSubresourceRange{
+			AspectMask: aspect,
+			LevelCount: vk.RemainingMipLevels,
+			LayerCount: vk.RemainingArrayLayers,
+		},
+	}}
+	vk.CmdPipelineBarrier(cmd, params.SrcStageMask, params.DstStageMask, 0, 0, nil, 0, nil, 1, barrier)
+	return nil
+}
@@ -0,0 +1,143 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// DeviceLimits holds the most practically useful subset of
+// vk.PhysicalDeviceLimits.
+type DeviceLimits struct {
+	// Image limits.
+	MaxImageDimension1D   uint32
+	MaxImageDimension2D   uint32
+	MaxImageDimension3D   uint32
+	MaxImageDimensionCube uint32
+	MaxImageArrayLayers   uint32
+
+	// Buffer limits.
+	MaxUniformBufferRange           uint32
+	MaxStorageBufferRange           uint32
+	MaxTexelBufferElements          uint32
+	MaxPushConstantsSize            uint32
+	MaxMemoryAllocationCount        uint32
+	MinMemoryMapAlignment           uint64
+	MinUniformBufferOffsetAlignment uint64
+	MinStorageBufferOffsetAlignment uint64
+	NonCoherentAtomSize             uint64
+
+	// Vertex input limits.
+	MaxVertexInputAttributes uint32
+	MaxVertexInputBindings   uint32
+
+	// Compute limits.
+	MaxComputeSharedMemorySize     uint32
+	MaxComputeWorkGroupCount       [3]uint32
+	MaxComputeWorkGroupInvocations uint32
+	MaxComputeWorkGroupSize        [3]uint32
+
+	// Framebuffer limits.
+	MaxFramebufferWidth  uint32
+	MaxFramebufferHeight uint32
+	MaxFramebufferLayers uint32
+	MaxColorAttachments  uint32
+	MaxViewports         uint32
+}
+
+// GetDeviceLimits returns the commonly used limits of gpu.
+func GetDeviceLimits(gpu vk.PhysicalDevice) DeviceLimits {
+	limits := getDeviceProperties(gpu).Limits
+	return DeviceLimits{
+		MaxImageDimension1D:   limits.MaxImageDimension1D,
+		MaxImageDimension2D:   limits.MaxImageDimension2D,
+		MaxImageDimension3D:   limits.MaxImageDimension3D,
+		MaxImageDimensionCube: limits.MaxImageDimensionCube,
+		MaxImageArrayLayers:   limits.MaxImageArrayLayers,
+
+		MaxUniformBufferRange:           limits.MaxUniformBufferRange,
+		MaxStorageBufferRange:           limits.MaxStorageBufferRange,
+		MaxTexelBufferElements:          limits.MaxTexelBufferElements,
+		MaxPushConstantsSize:            limits.MaxPushConstantsSize,
+		MaxMemoryAllocationCount:        limits.MaxMemoryAllocationCount,
+		MinMemoryMapAlignment:           uint64(limits.MinMemoryMapAlignment),
+		MinUniformBufferOffsetAlignment: uint64(limits.MinUniformBufferOffsetAlignment),
+		MinStorageBufferOffsetAlignment: uint64(limits.MinStorageBufferOffsetAlignment),
+		NonCoherentAtomSize:             uint64(limits.NonCoherentAtomSize),
+
+		MaxVertexInputAttributes: limits.MaxVertexInputAttributes,
+		MaxVertexInputBindings:   limits.MaxVertexInputBindings,
+
+		MaxComputeSharedMemorySize:     limits.MaxComputeSharedMemorySize,
+		MaxComputeWorkGroupCount:       limits.MaxComputeWorkGroupCount,
+		MaxComputeWorkGroupInvocations: limits.MaxComputeWorkGroupInvocations,
+		MaxComputeWorkGroupSize:        limits.MaxComputeWorkGroupSize,
+
+		MaxFramebufferWidth:  limits.MaxFramebufferWidth,
+		MaxFramebufferHeight: limits.MaxFramebufferHeight,
+		MaxFramebufferLayers: limits.MaxFramebufferLayers,
+		MaxColorAttachments:  limits.MaxColorAttachments,
+		MaxViewports:         limits.MaxViewports,
+	}
+}
+
+// PrintDeviceLimits prints the commonly used limits of the GPU at gpuIndex,
+// grouped by category.
+func PrintDeviceLimits(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	limits := GetDeviceLimits(v.gpuDevices[gpuIndex])
+
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Device Limits", gpuIndex))
+
+	addSection(table, "Image")
+	table.AddRow("Max Image Dimension 1D", limits.MaxImageDimension1D)
+	table.AddRow("Max Image Dimension 2D", limits.MaxImageDimension2D)
+	table.AddRow("Max Image Dimension 3D", limits.MaxImageDimension3D)
+	table.AddRow("Max Image Dimension Cube", limits.MaxImageDimensionCube)
+	table.AddRow("Max Image Array Layers", limits.MaxImageArrayLayers)
+
+	addSection(table, "Buffer")
+	table.AddRow("Max Uniform Buffer Range", limits.MaxUniformBufferRange)
+	table.AddRow("Max Storage Buffer Range", limits.MaxStorageBufferRange)
+	table.AddRow("Max Texel Buffer Elements", limits.MaxTexelBufferElements)
+	table.AddRow("Max Push Constants Size", limits.MaxPushConstantsSize)
+	table.AddRow("Max Memory Allocation Count", limits.MaxMemoryAllocationCount)
+	table.AddRow("Min Memory Map Alignment", limits.MinMemoryMapAlignment)
+	table.AddRow("Min Uniform Buffer Offset Alignment", limits.MinUniformBufferOffsetAlignment)
+	table.AddRow("Min Storage Buffer Offset Alignment", limits.MinStorageBufferOffsetAlignment)
+	table.AddRow("Non Coherent Atom Size", limits.NonCoherentAtomSize)
+
+	addSection(table, "Vertex Input")
+	table.AddRow("Max Vertex Input Attributes", limits.MaxVertexInputAttributes)
+	table.AddRow("Max Vertex Input Bindings", limits.MaxVertexInputBindings)
+
+	addSection(table, "Compute")
+	table.AddRow("Max Compute Shared Memory Size", limits.MaxComputeSharedMemorySize)
+	table.AddRow("Max Compute Work Group Count", formatUint32s(limits.MaxComputeWorkGroupCount[:]))
+	table.AddRow("Max Compute Work Group Invocations", limits.MaxComputeWorkGroupInvocations)
+	table.AddRow("Max Compute Work Group Size", formatUint32s(limits.MaxComputeWorkGroupSize[:]))
+
+	addSection(table, "Framebuffer")
+	table.AddRow("Max Framebuffer Width", limits.MaxFramebufferWidth)
+	table.AddRow("Max Framebuffer Height", limits.MaxFramebufferHeight)
+	table.AddRow("Max Framebuffer Layers", limits.MaxFramebufferLayers)
+	table.AddRow("Max Color Attachments", limits.MaxColorAttachments)
+	table.AddRow("Max Viewports", limits.MaxViewports)
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
+
+// formatUint32s renders values as "[x, y, z]".
+func formatUint32s(values []uint32) string {
+	s := "["
+	for i, value := range values {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprint(value)
+	}
+	return s + "]"
+}
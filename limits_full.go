@@ -0,0 +1,159 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// PrintAllDeviceLimits prints every field of vk.PhysicalDeviceLimits for the
+// GPU at gpuIndex in the order the Vulkan specification lists them.
+func PrintAllDeviceLimits(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	limits := getDeviceProperties(v.gpuDevices[gpuIndex]).Limits
+
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Device Limits (All)", gpuIndex))
+	table.AddHeaders("Limit", "Value")
+	table.AddRow("Max Image Dimension 1D", limits.MaxImageDimension1D)
+	table.AddRow("Max Image Dimension 2D", limits.MaxImageDimension2D)
+	table.AddRow("Max Image Dimension 3D", limits.MaxImageDimension3D)
+	table.AddRow("Max Image Dimension Cube", limits.MaxImageDimensionCube)
+	table.AddRow("Max Image Array Layers", limits.MaxImageArrayLayers)
+	table.AddRow("Max Texel Buffer Elements", limits.MaxTexelBufferElements)
+	table.AddRow("Max Uniform Buffer Range", formatBytes(uint64(limits.MaxUniformBufferRange)))
+	table.AddRow("Max Storage Buffer Range", formatBytes(uint64(limits.MaxStorageBufferRange)))
+	table.AddRow("Max Push Constants Size", formatBytes(uint64(limits.MaxPushConstantsSize)))
+	table.AddRow("Max Memory Allocation Count", limits.MaxMemoryAllocationCount)
+	table.AddRow("Max Sampler Allocation Count", limits.MaxSamplerAllocationCount)
+	table.AddRow("Buffer Image Granularity", formatBytes(uint64(limits.BufferImageGranularity)))
+	table.AddRow("Sparse Address Space Size", formatBytes(uint64(limits.SparseAddressSpaceSize)))
+	table.AddRow("Max Bound Descriptor Sets", limits.MaxBoundDescriptorSets)
+	table.AddRow("Max Per Stage Descriptor Samplers", limits.MaxPerStageDescriptorSamplers)
+	table.AddRow("Max Per Stage Descriptor Uniform Buffers", limits.MaxPerStageDescriptorUniformBuffers)
+	table.AddRow("Max Per Stage Descriptor Storage Buffers", limits.MaxPerStageDescriptorStorageBuffers)
+	table.AddRow("Max Per Stage Descriptor Sampled Images", limits.MaxPerStageDescriptorSampledImages)
+	table.AddRow("Max Per Stage Descriptor Storage Images", limits.MaxPerStageDescriptorStorageImages)
+	table.AddRow("Max Per Stage Descriptor Input Attachments", limits.MaxPerStageDescriptorInputAttachments)
+	table.AddRow("Max Per Stage Resources", limits.MaxPerStageResources)
+	table.AddRow("Max Descriptor Set Samplers", limits.MaxDescriptorSetSamplers)
+	table.AddRow("Max Descriptor Set Uniform Buffers", limits.MaxDescriptorSetUniformBuffers)
+	table.AddRow("Max Descriptor Set Uniform Buffers Dynamic", limits.MaxDescriptorSetUniformBuffersDynamic)
+	table.AddRow("Max Descriptor Set Storage Buffers", limits.MaxDescriptorSetStorageBuffers)
+	table.AddRow("Max Descriptor Set Storage Buffers Dynamic", limits.MaxDescriptorSetStorageBuffersDynamic)
+	table.AddRow("Max Descriptor Set Sampled Images", limits.MaxDescriptorSetSampledImages)
+	table.AddRow("Max Descriptor Set Storage Images", limits.MaxDescriptorSetStorageImages)
+	table.AddRow("Max Descriptor Set Input Attachments", limits.MaxDescriptorSetInputAttachments)
+	table.AddRow("Max Vertex Input Attributes", limits.MaxVertexInputAttributes)
+	table.AddRow("Max Vertex Input Bindings", limits.MaxVertexInputBindings)
+	table.AddRow("Max Vertex Input Attribute Offset", limits.MaxVertexInputAttributeOffset)
+	table.AddRow("Max Vertex Input Binding Stride", limits.MaxVertexInputBindingStride)
+	table.AddRow("Max Vertex Output Components", limits.MaxVertexOutputComponents)
+	table.AddRow("Max Tessellation Generation Level", limits.MaxTessellationGenerationLevel)
+	table.AddRow("Max Tessellation Patch Size", limits.MaxTessellationPatchSize)
+	table.AddRow("Max Tessellation Control Per Vertex Input Components", limits.MaxTessellationControlPerVertexInputComponents)
+	table.AddRow("Max Tessellation Control Per Vertex Output Components", limits.MaxTessellationControlPerVertexOutputComponents)
+	table.AddRow("Max Tessellation Control Per Patch Output Components", limits.MaxTessellationControlPerPatchOutputComponents)
+	table.AddRow("Max Tessellation Control Total Output Components", limits.MaxTessellationControlTotalOutputComponents)
+	table.AddRow("Max Tessellation Evaluation Input Components", limits.MaxTessellationEvaluationInputComponents)
+	table.AddRow("Max Tessellation Evaluation Output Components", limits.MaxTessellationEvaluationOutputComponents)
+	table.AddRow("Max Geometry Shader Invocations", limits.MaxGeometryShaderInvocations)
+	table.AddRow("Max Geometry Input Components", limits.MaxGeometryInputComponents)
+	table.AddRow("Max Geometry Output Components", limits.MaxGeometryOutputComponents)
+	table.AddRow("Max Geometry Output Vertices", limits.MaxGeometryOutputVertices)
+	table.AddRow("Max Geometry Total Output Components", limits.MaxGeometryTotalOutputComponents)
+	table.AddRow("Max Fragment Input Components", limits.MaxFragmentInputComponents)
+	table.AddRow("Max Fragment Output Attachments", limits.MaxFragmentOutputAttachments)
+	table.AddRow("Max Fragment Dual Src Attachments", limits.MaxFragmentDualSrcAttachments)
+	table.AddRow("Max Fragment Combined Output Resources", limits.MaxFragmentCombinedOutputResources)
+	table.AddRow("Max Compute Shared Memory Size", formatBytes(uint64(limits.MaxComputeSharedMemorySize)))
+	table.AddRow("Max Compute Work Group Count", formatUint32s(limits.MaxComputeWorkGroupCount[:]))
+	table.AddRow("Max Compute Work Group Invocations", limits.MaxComputeWorkGroupInvocations)
+	table.AddRow("Max Compute Work Group Size", formatUint32s(limits.MaxComputeWorkGroupSize[:]))
+	table.AddRow("Sub Pixel Precision Bits", limits.SubPixelPrecisionBits)
+	table.AddRow("Sub Texel Precision Bits", limits.SubTexelPrecisionBits)
+	table.AddRow("Mipmap Precision Bits", limits.MipmapPrecisionBits)
+	table.AddRow("Max Draw Indexed Index Value", limits.MaxDrawIndexedIndexValue)
+	table.AddRow("Max Draw Indirect Count", limits.MaxDrawIndirectCount)
+	table.AddRow("Max Sampler Lod Bias", limits.MaxSamplerLodBias)
+	table.AddRow("Max Sampler Anisotropy", limits.MaxSamplerAnisotropy)
+	table.AddRow("Max Viewports", limits.MaxViewports)
+	table.AddRow("Max Viewport Dimensions", formatUint32s(limits.MaxViewportDimensions[:]))
+	table.AddRow("Viewport Bounds Range", formatFloat32s(limits.ViewportBoundsRange[:]))
+	table.AddRow("Viewport Sub Pixel Bits", limits.ViewportSubPixelBits)
+	table.AddRow("Min Memory Map Alignment", formatBytes(uint64(limits.MinMemoryMapAlignment)))
+	table.AddRow("Min Texel Buffer Offset Alignment", formatBytes(uint64(limits.MinTexelBufferOffsetAlignment)))
+	table.AddRow("Min Uniform Buffer Offset Alignment", formatBytes(uint64(limits.MinUniformBufferOffsetAlignment)))
+	table.AddRow("Min Storage Buffer Offset Alignment", formatBytes(uint64(limits.MinStorageBufferOffsetAlignment)))
+	table.AddRow("Min Texel Offset", limits.MinTexelOffset)
+	table.AddRow("Max Texel Offset", limits.MaxTexelOffset)
+	table.AddRow("Min Texel Gather Offset", limits.MinTexelGatherOffset)
+	table.AddRow("Max Texel Gather Offset", limits.MaxTexelGatherOffset)
+	table.AddRow("Min Interpolation Offset", limits.MinInterpolationOffset)
+	table.AddRow("Max Interpolation Offset", limits.MaxInterpolationOffset)
+	table.AddRow("Sub Pixel Interpolation Offset Bits", limits.SubPixelInterpolationOffsetBits)
+	table.AddRow("Max Framebuffer Width", limits.MaxFramebufferWidth)
+	table.AddRow("Max Framebuffer Height", limits.MaxFramebufferHeight)
+	table.AddRow("Max Framebuffer Layers", limits.MaxFramebufferLayers)
+	table.AddRow("Framebuffer Color Sample Counts", sampleCountFlags(limits.FramebufferColorSampleCounts))
+	table.AddRow("Framebuffer Depth Sample Counts", sampleCountFlags(limits.FramebufferDepthSampleCounts))
+	table.AddRow("Framebuffer Stencil Sample Counts", sampleCountFlags(limits.FramebufferStencilSampleCounts))
+	table.AddRow("Framebuffer No Attachments Sample Counts", sampleCountFlags(limits.FramebufferNoAttachmentsSampleCounts))
+	table.AddRow("Max Color Attachments", limits.MaxColorAttachments)
+	table.AddRow("Sampled Image Color Sample Counts", sampleCountFlags(limits.SampledImageColorSampleCounts))
+	table.AddRow("Sampled Image Integer Sample Counts", sampleCountFlags(limits.SampledImageIntegerSampleCounts))
+	table.AddRow("Sampled Image Depth Sample Counts", sampleCountFlags(limits.SampledImageDepthSampleCounts))
+	table.AddRow("Sampled Image Stencil Sample Counts", sampleCountFlags(limits.SampledImageStencilSampleCounts))
+	table.AddRow("Storage Image Sample Counts", sampleCountFlags(limits.StorageImageSampleCounts))
+	table.AddRow("Max Sample Mask Words", limits.MaxSampleMaskWords)
+	table.AddRow("Timestamp Compute And Graphics", checkMark(limits.TimestampComputeAndGraphics.B()))
+	table.AddRow("Timestamp Period", limits.TimestampPeriod)
+	table.AddRow("Max Clip Distances", limits.MaxClipDistances)
+	table.AddRow("Max Cull Distances", limits.MaxCullDistances)
+	table.AddRow("Max Combined Clip And Cull Distances", limits.MaxCombinedClipAndCullDistances)
+	table.AddRow("Discrete Queue Priorities", limits.DiscreteQueuePriorities)
+	table.AddRow("Point Size Range", formatFloat32s(limits.PointSizeRange[:]))
+	table.AddRow("Line Width Range", formatFloat32s(limits.LineWidthRange[:]))
+	table.AddRow("Point Size Granularity", limits.PointSizeGranularity)
+	table.AddRow("Line Width Granularity", limits.LineWidthGranularity)
+	table.AddRow("Strict Lines", checkMark(limits.StrictLines.B()))
+	table.AddRow("Standard Sample Locations", checkMark(limits.StandardSampleLocations.B()))
+	table.AddRow("Optimal Buffer Copy Offset Alignment", formatBytes(uint64(limits.OptimalBufferCopyOffsetAlignment)))
+	table.AddRow("Optimal Buffer Copy Row Pitch Alignment", formatBytes(uint64(limits.OptimalBufferCopyRowPitchAlignment)))
+	table.AddRow("Non Coherent Atom Size", formatBytes(uint64(limits.NonCoherentAtomSize)))
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
+
+var sampleCountFlagTable = []flagName{
+	{uint32(vk.SampleCount1Bit), "1"},
+	{uint32(vk.SampleCount2Bit), "2"},
+	{uint32(vk.SampleCount4Bit), "4"},
+	{uint32(vk.SampleCount8Bit), "8"},
+	{uint32(vk.SampleCount16Bit), "16"},
+	{uint32(vk.SampleCount32Bit), "32"},
+	{uint32(vk.SampleCount64Bit), "64"},
+}
+
+func sampleCountFlags(flags vk.SampleCountFlags) string {
+	return joinFlags(flagNames(uint32(flags), sampleCountFlagTable))
+}
+
+// formatBytes renders a size or alignment as "256 bytes".
+func formatBytes(n uint64) string {
+	return fmt.Sprintf("%d bytes", n)
+}
+
+// formatFloat32s renders values as "[x, y]".
+func formatFloat32s(values []float32) string {
+	s := "["
+	for i, value := range values {
+		if i > 0 {
+			s += ", "
+		}
+		s += fmt.Sprint(value)
+	}
+	return s + "]"
+}
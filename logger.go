@@ -0,0 +1,76 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Logger receives the diagnostic output of the package. args are
+// alternating keys and values, as with log/slog, so an *slog.Logger or a
+// thin zap or logrus adapter satisfies it.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// WithLogger routes the diagnostic output of NewVulkanDevice and the
+// functions using the device to logger. Without it the output is discarded.
+func WithLogger(logger Logger) Option {
+	return func(c *deviceConfig) {
+		c.logger = logger
+	}
+}
+
+// DefaultLogger returns a Logger writing to log.Default(). Debug messages
+// are dropped.
+func DefaultLogger() Logger {
+	return stdLogger{log.Default()}
+}
+
+// discardLogger is the Logger used when WithLogger isn't given, so the
+// package prints nothing on its own.
+type discardLogger struct{}
+
+func (discardLogger) Debug(msg string, args ...any) {}
+func (discardLogger) Info(msg string, args ...any)  {}
+func (discardLogger) Warn(msg string, args ...any)  {}
+func (discardLogger) Error(msg string, args ...any) {}
+
+// stdLogger adapts a *log.Logger to Logger, formatting args as key=value.
+type stdLogger struct {
+	logger *log.Logger
+}
+
+func (l stdLogger) Debug(msg string, args ...any) {}
+
+func (l stdLogger) Info(msg string, args ...any) {
+	l.logger.Print(formatLogLine("INFO", msg, args))
+}
+
+func (l stdLogger) Warn(msg string, args ...any) {
+	l.logger.Print(formatLogLine("WARN", msg, args))
+}
+
+func (l stdLogger) Error(msg string, args ...any) {
+	l.logger.Print(formatLogLine("ERROR", msg, args))
+}
+
+// formatLogLine renders "LEVEL msg key=value ...". A trailing key without a
+// value is reported under !BADKEY, as log/slog does.
+func formatLogLine(level, msg string, args []any) string {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteString(" ")
+	b.WriteString(msg)
+	for i := 0; i < len(args); i += 2 {
+		if i+1 == len(args) {
+			fmt.Fprintf(&b, " !BADKEY=%v", args[i])
+			break
+		}
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+	return b.String()
+}
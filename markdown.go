@@ -0,0 +1,118 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// PrintMarkdown writes the GPUs at gpuIndexes, or every GPU if none are
+// given, to w as GitHub-flavored Markdown for pasting into issues: a
+// heading per GPU, key/value, queue family and memory tables, and the
+// extensions and features in collapsed <details> blocks.
+func PrintMarkdown(w io.Writer, v *VulkanDeviceInfo, gpuIndexes ...int) error {
+	report, err := GatherDeviceReport(v)
+	if err != nil {
+		return err
+	}
+	if len(gpuIndexes) == 0 {
+		for i := range report.Devices {
+			gpuIndexes = append(gpuIndexes, i)
+		}
+	}
+
+	fmt.Fprintln(w, "# Vulkan Devices")
+	fmt.Fprintln(w)
+	writeMarkdownTable(w, []string{"Key", "Value"}, [][]string{
+		{"Physical GPUs", fmt.Sprint(report.GPUCount)},
+		{"Loader Instance Version", report.LoaderVersion.Version},
+		{"Instance API Version", report.InstanceAPIVersion.Version},
+	})
+	for _, i := range gpuIndexes {
+		if i < 0 || i >= len(report.Devices) {
+			err := fmt.Errorf("PrintMarkdown: GPU index %d out of range", i)
+			return err
+		}
+		writeMarkdownDevice(w, report.Devices[i])
+	}
+	return nil
+}
+
+func writeMarkdownDevice(w io.Writer, d DeviceReport) {
+	fmt.Fprintf(w, "\n## GPU %d: %s\n\n", d.Index, d.Name)
+	rows := [][]string{
+		{"Vendor", formatVendor(d.VendorID)},
+		{"Device ID", fmt.Sprintf("0x%x", d.DeviceID)},
+		{"Device Type", d.DeviceType},
+		{"API Version", d.APIVersion.Version},
+		{"Driver Version", d.DriverVersion.Version},
+	}
+	if d.DriverName != "" {
+		rows = append(rows, []string{"Driver Name", d.DriverName}, []string{"Driver Info", d.DriverInfo})
+	}
+	if d.PCIBusInfo != nil {
+		rows = append(rows, []string{"PCI Address", d.PCIBusInfo.Address})
+	}
+	if d.PortabilitySubset {
+		rows = append(rows, []string{"Portability Subset", "Yes"})
+	}
+	writeMarkdownTable(w, []string{"Key", "Value"}, rows)
+
+	fmt.Fprintln(w, "\n### Queue Families")
+	fmt.Fprintln(w)
+	rows = nil
+	for _, family := range d.QueueFamilies {
+		rows = append(rows, []string{fmt.Sprint(family.Index), fmt.Sprint(family.QueueCount), joinFlags(family.Flags)})
+	}
+	writeMarkdownTable(w, []string{"Family", "Queues", "Flags"}, rows)
+
+	fmt.Fprintln(w, "\n### Memory Heaps")
+	fmt.Fprintln(w)
+	rows = nil
+	for _, heap := range d.MemoryHeaps {
+		rows = append(rows, []string{fmt.Sprint(heap.Index), formatGiB(heap.Size), joinFlags(heap.Flags)})
+	}
+	writeMarkdownTable(w, []string{"Heap", "Size", "Flags"}, rows)
+
+	rows = nil
+	for _, extension := range d.Extensions {
+		rows = append(rows, []string{extension.Name, fmt.Sprint(extension.SpecVersion)})
+	}
+	writeMarkdownDetails(w, fmt.Sprintf("Device Extensions (%d)", len(d.Extensions)),
+		[]string{"Extension", "Spec Version"}, rows)
+
+	rows = nil
+	for _, f := range deviceFeatures {
+		rows = append(rows, []string{f.name, checkMark(d.Features[f.name])})
+	}
+	writeMarkdownDetails(w, "Features", []string{"Feature", "Supported"}, rows)
+}
+
+// writeMarkdownDetails writes a table inside a collapsed <details> block
+// titled summary.
+func writeMarkdownDetails(w io.Writer, summary string, headers []string, rows [][]string) {
+	fmt.Fprintf(w, "\n<details>\n<summary>%s</summary>\n\n", summary)
+	writeMarkdownTable(w, headers, rows)
+	fmt.Fprintln(w, "\n</details>")
+}
+
+func writeMarkdownTable(w io.Writer, headers []string, rows [][]string) {
+	fmt.Fprintln(w, markdownRow(headers))
+	separator := make([]string, len(headers))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	fmt.Fprintln(w, markdownRow(separator))
+	for _, row := range rows {
+		fmt.Fprintln(w, markdownRow(row))
+	}
+}
+
+// markdownRow renders cells as a table row, escaping the pipes in them.
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.ReplaceAll(cell, "|", `\|`)
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
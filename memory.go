@@ -0,0 +1,184 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// GetMemoryProperties returns the memory heaps and memory types of gpu.
+func GetMemoryProperties(gpu vk.PhysicalDevice) vk.PhysicalDeviceMemoryProperties {
+	var memoryProperties vk.PhysicalDeviceMemoryProperties
+	vk.GetPhysicalDeviceMemoryProperties(gpu, &memoryProperties)
+	memoryProperties.Deref()
+	for i := uint32(0); i < memoryProperties.MemoryHeapCount; i++ {
+		memoryProperties.MemoryHeaps[i].Deref()
+	}
+	for i := uint32(0); i < memoryProperties.MemoryTypeCount; i++ {
+		memoryProperties.MemoryTypes[i].Deref()
+	}
+	return memoryProperties
+}
+
+// PrintMemoryInfo prints the memory heaps and memory types of the GPU at
+// gpuIndex. Heap budget and usage are included when the GPU supports
+// VK_EXT_memory_budget.
+func PrintMemoryInfo(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	memoryProperties := GetMemoryProperties(v.gpuDevices[gpuIndex])
+	budget, hasBudget := getMemoryBudget(v, gpuIndex)
+
+	heaps := tablewriter.CreateTable()
+	heaps.UTF8Box()
+	heaps.AddTitle(fmt.Sprintf("GPU %d Memory Heaps", gpuIndex))
+	if hasBudget {
+		heaps.AddHeaders("Heap", "Size (MiB)", "Size", "Budget", "Usage", "Utilization", "Flags")
+	} else {
+		heaps.AddHeaders("Heap", "Size (MiB)", "Size", "Flags")
+	}
+	for i := uint32(0); i < memoryProperties.MemoryHeapCount; i++ {
+		heap := memoryProperties.MemoryHeaps[i]
+		if hasBudget {
+			heaps.AddRow(i, uint64(heap.Size)>>20, formatGiB(uint64(heap.Size)),
+				formatGiB(budget.HeapBudget[i]), formatGiB(budget.HeapUsage[i]),
+				formatUtilization(budget.HeapUsage[i], budget.HeapBudget[i]), memoryHeapFlags(heap.Flags))
+		} else {
+			heaps.AddRow(i, uint64(heap.Size)>>20, formatGiB(uint64(heap.Size)), memoryHeapFlags(heap.Flags))
+		}
+	}
+
+	types := tablewriter.CreateTable()
+	types.UTF8Box()
+	types.AddTitle(fmt.Sprintf("GPU %d Memory Types", gpuIndex))
+	types.AddHeaders("Type", "Heap", "Flags")
+	for i := uint32(0); i < memoryProperties.MemoryTypeCount; i++ {
+		memoryType := memoryProperties.MemoryTypes[i]
+		types.AddRow(i, memoryType.HeapIndex, memoryPropertyFlags(memoryType.PropertyFlags))
+	}
+
+	fmt.Fprintln(w, "\n"+heaps.Render())
+	fmt.Fprintln(w, "\n"+types.Render())
+}
+
+var memoryHeapFlagTable = []flagName{
+	{uint32(vk.MemoryHeapDeviceLocalBit), "DEVICE_LOCAL"},
+	{uint32(vk.MemoryHeapMultiInstanceBit), "MULTI_INSTANCE"},
+}
+
+var memoryPropertyFlagTable = []flagName{
+	{uint32(vk.MemoryPropertyDeviceLocalBit), "DEVICE_LOCAL"},
+	{uint32(vk.MemoryPropertyHostVisibleBit), "HOST_VISIBLE"},
+	{uint32(vk.MemoryPropertyHostCoherentBit), "HOST_COHERENT"},
+	{uint32(vk.MemoryPropertyHostCachedBit), "HOST_CACHED"},
+	{uint32(vk.MemoryPropertyLazilyAllocatedBit), "LAZILY_ALLOCATED"},
+	{uint32(vk.MemoryPropertyProtectedBit), "PROTECTED"},
+}
+
+func memoryHeapFlags(flags vk.MemoryHeapFlags) string {
+	return joinFlags(memoryHeapFlagNames(flags))
+}
+
+func memoryHeapFlagNames(flags vk.MemoryHeapFlags) []string {
+	return flagNames(uint32(flags), memoryHeapFlagTable)
+}
+
+func memoryPropertyFlags(flags vk.MemoryPropertyFlags) string {
+	return joinFlags(memoryPropertyFlagNames(flags))
+}
+
+func memoryPropertyFlagNames(flags vk.MemoryPropertyFlags) []string {
+	return flagNames(uint32(flags), memoryPropertyFlagTable)
+}
+
+func formatGiB(size uint64) string {
+	return fmt.Sprintf("%.2f GiB", float64(size)/(1<<30))
+}
+
+// structureTypePhysicalDeviceMemoryBudgetProperties is the structure type
+// of VkPhysicalDeviceMemoryBudgetPropertiesEXT.
+const structureTypePhysicalDeviceMemoryBudgetProperties vk.StructureType = 1000237000
+
+// physicalDeviceMemoryBudgetProperties has the C layout of
+// VkPhysicalDeviceMemoryBudgetPropertiesEXT.
+type physicalDeviceMemoryBudgetProperties struct {
+	chainHeader
+	HeapBudget [vk.MaxMemoryHeaps]uint64
+	HeapUsage  [vk.MaxMemoryHeaps]uint64
+}
+
+// getMemoryBudget queries the per-heap budget and usage of the GPU at
+// gpuIndex. ok is false if the GPU doesn't support VK_EXT_memory_budget or
+// properties2 queries are unavailable.
+func getMemoryBudget(v *VulkanDeviceInfo, gpuIndex int) (budget physicalDeviceMemoryBudgetProperties, ok bool) {
+	gpu := v.gpuDevices[gpuIndex]
+	extensions, err := EnumerateDeviceExtensions(gpu)
+	if err != nil || !hasExtension(extensions, "VK_EXT_memory_budget") {
+		return budget, false
+	}
+	budget.SType = structureTypePhysicalDeviceMemoryBudgetProperties
+	var chain structChain
+	chain.add(unsafe.Pointer(&budget), unsafe.Sizeof(budget))
+	return budget, queryMemoryProperties2(v, gpu, &chain)
+}
+
+// MemoryHeapBudget is the VK_EXT_memory_budget budget and current usage of
+// a memory heap, in bytes. Budget is how much the process can allocate from
+// the heap before allocations may fail or hurt performance; it changes with
+// the load of other processes.
+type MemoryHeapBudget struct {
+	HeapIndex uint32
+	Budget    uint64
+	Usage     uint64
+}
+
+// GetMemoryBudget returns the budget and usage of each memory heap of the
+// GPU at gpuIndex. It fails with ErrExtensionNotSupported if the GPU doesn't
+// support VK_EXT_memory_budget or the instance can't make properties2
+// queries.
+func GetMemoryBudget(v *VulkanDeviceInfo, gpuIndex int) ([]MemoryHeapBudget, error) {
+	budget, ok := getMemoryBudget(v, gpuIndex)
+	if !ok {
+		err := fmt.Errorf("%w: VK_EXT_memory_budget on GPU %d", ErrExtensionNotSupported, gpuIndex)
+		return nil, err
+	}
+	memoryProperties := GetMemoryProperties(v.gpuDevices[gpuIndex])
+	heaps := make([]MemoryHeapBudget, memoryProperties.MemoryHeapCount)
+	for i := range heaps {
+		heaps[i] = MemoryHeapBudget{
+			HeapIndex: uint32(i),
+			Budget:    budget.HeapBudget[i],
+			Usage:     budget.HeapUsage[i],
+		}
+	}
+	return heaps, nil
+}
+
+// PrintMemoryBudget prints the budget, usage and utilization of each memory
+// heap of the GPU at gpuIndex.
+func PrintMemoryBudget(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Memory Budget", gpuIndex))
+	heaps, err := GetMemoryBudget(v, gpuIndex)
+	if err != nil {
+		table.AddRow("Error", err)
+	} else {
+		table.AddHeaders("Heap", "Budget", "Usage", "Utilization")
+		for _, heap := range heaps {
+			table.AddRow(heap.HeapIndex, formatGiB(heap.Budget), formatGiB(heap.Usage),
+				formatUtilization(heap.Usage, heap.Budget))
+		}
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
+
+// formatUtilization renders usage as a percentage of budget.
+func formatUtilization(usage, budget uint64) string {
+	if budget == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f%%", float64(usage)*100/float64(budget))
+}
@@ -0,0 +1,214 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// Extensions providing task and mesh shaders. VK_NV_mesh_shader predates
+// the cross-vendor extension and has different limits.
+const (
+	meshShaderExtensionName   = "VK_EXT_mesh_shader"
+	meshShaderNVExtensionName = "VK_NV_mesh_shader"
+)
+
+// Structure types of the VK_EXT_mesh_shader structures, which are newer than
+// the vulkan-go bindings.
+const (
+	structureTypePhysicalDeviceMeshShaderFeatures   vk.StructureType = 1000328000
+	structureTypePhysicalDeviceMeshShaderProperties vk.StructureType = 1000328001
+)
+
+// physicalDeviceMeshShaderFeatures has the C layout of
+// VkPhysicalDeviceMeshShaderFeaturesEXT.
+type physicalDeviceMeshShaderFeatures struct {
+	chainHeader
+	TaskShader                             vk.Bool32
+	MeshShader                             vk.Bool32
+	MultiviewMeshShader                    vk.Bool32
+	PrimitiveFragmentShadingRateMeshShader vk.Bool32
+	MeshShaderQueries                      vk.Bool32
+}
+
+// physicalDeviceMeshShaderProperties has the C layout of
+// VkPhysicalDeviceMeshShaderPropertiesEXT.
+type physicalDeviceMeshShaderProperties struct {
+	chainHeader
+	MaxTaskWorkGroupTotalCount            uint32
+	MaxTaskWorkGroupCount                 [3]uint32
+	MaxTaskWorkGroupInvocations           uint32
+	MaxTaskWorkGroupSize                  [3]uint32
+	MaxTaskPayloadSize                    uint32
+	MaxTaskSharedMemorySize               uint32
+	MaxTaskPayloadAndSharedMemorySize     uint32
+	MaxMeshWorkGroupTotalCount            uint32
+	MaxMeshWorkGroupCount                 [3]uint32
+	MaxMeshWorkGroupInvocations           uint32
+	MaxMeshWorkGroupSize                  [3]uint32
+	MaxMeshSharedMemorySize               uint32
+	MaxMeshPayloadAndSharedMemorySize     uint32
+	MaxMeshOutputMemorySize               uint32
+	MaxMeshPayloadAndOutputMemorySize     uint32
+	MaxMeshOutputComponents               uint32
+	MaxMeshOutputVertices                 uint32
+	MaxMeshOutputPrimitives               uint32
+	MaxMeshOutputLayers                   uint32
+	MaxMeshMultiviewViewCount             uint32
+	MeshOutputPerVertexGranularity        uint32
+	MeshOutputPerPrimitiveGranularity     uint32
+	MaxPreferredTaskWorkGroupInvocations  uint32
+	MaxPreferredMeshWorkGroupInvocations  uint32
+	PrefersLocalInvocationVertexOutput    vk.Bool32
+	PrefersLocalInvocationPrimitiveOutput vk.Bool32
+	PrefersCompactVertexOutput            vk.Bool32
+	PrefersCompactPrimitiveOutput         vk.Bool32
+}
+
+// physicalDeviceMeshShaderFeaturesNV has the C layout of
+// VkPhysicalDeviceMeshShaderFeaturesNV.
+type physicalDeviceMeshShaderFeaturesNV struct {
+	chainHeader
+	TaskShader vk.Bool32
+	MeshShader vk.Bool32
+}
+
+// physicalDeviceMeshShaderPropertiesNV has the C layout of
+// VkPhysicalDeviceMeshShaderPropertiesNV.
+type physicalDeviceMeshShaderPropertiesNV struct {
+	chainHeader
+	MaxDrawMeshTasksCount             uint32
+	MaxTaskWorkGroupInvocations       uint32
+	MaxTaskWorkGroupSize              [3]uint32
+	MaxTaskTotalMemorySize            uint32
+	MaxTaskOutputCount                uint32
+	MaxMeshWorkGroupInvocations       uint32
+	MaxMeshWorkGroupSize              [3]uint32
+	MaxMeshTotalMemorySize            uint32
+	MaxMeshOutputVertices             uint32
+	MaxMeshOutputPrimitives           uint32
+	MaxMeshMultiviewViewCount         uint32
+	MeshOutputPerVertexGranularity    uint32
+	MeshOutputPerPrimitiveGranularity uint32
+}
+
+// MeshShaderInfo holds the task and mesh shader support of a GPU, from
+// VK_EXT_mesh_shader or, failing that, VK_NV_mesh_shader.
+type MeshShaderInfo struct {
+	// Extension is the extension the limits come from, or "" if the GPU
+	// advertises neither.
+	Extension string `json:"extension"`
+
+	TaskShader bool `json:"task_shader"`
+	MeshShader bool `json:"mesh_shader"`
+
+	// MaxMeshWorkGroupTotalCount and the preferred invocation counts are
+	// only reported by VK_EXT_mesh_shader.
+	MaxMeshWorkGroupTotalCount           uint32    `json:"max_mesh_work_group_total_count,omitempty"`
+	MaxMeshWorkGroupSize                 [3]uint32 `json:"max_mesh_work_group_size"`
+	MaxMeshOutputVertices                uint32    `json:"max_mesh_output_vertices"`
+	MaxMeshOutputPrimitives              uint32    `json:"max_mesh_output_primitives"`
+	MaxPreferredTaskWorkGroupInvocations uint32    `json:"max_preferred_task_work_group_invocations,omitempty"`
+	MaxPreferredMeshWorkGroupInvocations uint32    `json:"max_preferred_mesh_work_group_invocations,omitempty"`
+	// MaxDrawMeshTasksCount is only reported by VK_NV_mesh_shader, which
+	// limits the task count of a draw instead of the work group count.
+	MaxDrawMeshTasksCount uint32 `json:"max_draw_mesh_tasks_count,omitempty"`
+}
+
+// NV reports whether the limits come from VK_NV_mesh_shader.
+func (info MeshShaderInfo) NV() bool {
+	return info.Extension == meshShaderNVExtensionName
+}
+
+// GetMeshShaderInfo queries the mesh shader properties and features of the
+// GPU at gpuIndex, from VK_EXT_mesh_shader if it advertises it and from
+// VK_NV_mesh_shader otherwise. A GPU with neither gets a zero
+// MeshShaderInfo and no error.
+func GetMeshShaderInfo(v *VulkanDeviceInfo, gpuIndex int) (MeshShaderInfo, error) {
+	var info MeshShaderInfo
+	extensions, err := EnumerateDeviceExtensions(v.gpuDevices[gpuIndex])
+	if err != nil {
+		return info, err
+	}
+	switch {
+	case hasExtension(extensions, meshShaderExtensionName):
+		properties := physicalDeviceMeshShaderProperties{chainHeader: chainHeader{SType: structureTypePhysicalDeviceMeshShaderProperties}}
+		features := physicalDeviceMeshShaderFeatures{chainHeader: chainHeader{SType: structureTypePhysicalDeviceMeshShaderFeatures}}
+		if _, err := GetPhysicalDeviceProperties2(v, gpuIndex, ChainedStruct{unsafe.Pointer(&properties), unsafe.Sizeof(properties)}); err != nil {
+			return info, err
+		}
+		if _, err := GetPhysicalDeviceFeatures2(v, gpuIndex, ChainedStruct{unsafe.Pointer(&features), unsafe.Sizeof(features)}); err != nil {
+			return info, err
+		}
+		info.Extension = meshShaderExtensionName
+		info.TaskShader = features.TaskShader.B()
+		info.MeshShader = features.MeshShader.B()
+		info.MaxMeshWorkGroupTotalCount = properties.MaxMeshWorkGroupTotalCount
+		info.MaxMeshWorkGroupSize = properties.MaxMeshWorkGroupSize
+		info.MaxMeshOutputVertices = properties.MaxMeshOutputVertices
+		info.MaxMeshOutputPrimitives = properties.MaxMeshOutputPrimitives
+		info.MaxPreferredTaskWorkGroupInvocations = properties.MaxPreferredTaskWorkGroupInvocations
+		info.MaxPreferredMeshWorkGroupInvocations = properties.MaxPreferredMeshWorkGroupInvocations
+	case hasExtension(extensions, meshShaderNVExtensionName):
+		properties := physicalDeviceMeshShaderPropertiesNV{chainHeader: chainHeader{SType: vk.StructureTypePhysicalDeviceMeshShaderPropertiesNv}}
+		features := physicalDeviceMeshShaderFeaturesNV{chainHeader: chainHeader{SType: vk.StructureTypePhysicalDeviceMeshShaderFeaturesNv}}
+		if _, err := GetPhysicalDeviceProperties2(v, gpuIndex, ChainedStruct{unsafe.Pointer(&properties), unsafe.Sizeof(properties)}); err != nil {
+			return info, err
+		}
+		if _, err := GetPhysicalDeviceFeatures2(v, gpuIndex, ChainedStruct{unsafe.Pointer(&features), unsafe.Sizeof(features)}); err != nil {
+			return info, err
+		}
+		info.Extension = meshShaderNVExtensionName
+		info.TaskShader = features.TaskShader.B()
+		info.MeshShader = features.MeshShader.B()
+		info.MaxMeshWorkGroupSize = properties.MaxMeshWorkGroupSize
+		info.MaxMeshOutputVertices = properties.MaxMeshOutputVertices
+		info.MaxMeshOutputPrimitives = properties.MaxMeshOutputPrimitives
+		info.MaxDrawMeshTasksCount = properties.MaxDrawMeshTasksCount
+	}
+	return info, nil
+}
+
+// PrintMeshShaderInfo prints the mesh shader limits and features of the GPU
+// at gpuIndex, naming the extension they come from. It prints nothing if
+// the GPU supports neither VK_EXT_mesh_shader nor VK_NV_mesh_shader.
+func PrintMeshShaderInfo(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	info, err := GetMeshShaderInfo(v, gpuIndex)
+	if err == nil && info.Extension == "" {
+		return
+	}
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Mesh Shaders", gpuIndex))
+	if err != nil {
+		table.AddRow("Error", err)
+		fmt.Fprintln(w, "\n"+table.Render())
+		return
+	}
+	if info.NV() {
+		table.AddRow("Extension", info.Extension+" (NVIDIA only, limits differ from EXT)")
+	} else {
+		table.AddRow("Extension", info.Extension)
+	}
+	addSection(table, "Features")
+	table.AddRow("taskShader", checkMark(info.TaskShader))
+	table.AddRow("meshShader", checkMark(info.MeshShader))
+	addSection(table, "Limits")
+	if info.NV() {
+		table.AddRow("maxDrawMeshTasksCount", info.MaxDrawMeshTasksCount)
+	} else {
+		table.AddRow("maxMeshWorkGroupTotalCount", info.MaxMeshWorkGroupTotalCount)
+	}
+	table.AddRow("maxMeshWorkGroupSize", formatUint32s(info.MaxMeshWorkGroupSize[:]))
+	table.AddRow("maxMeshOutputVertices", info.MaxMeshOutputVertices)
+	table.AddRow("maxMeshOutputPrimitives", info.MaxMeshOutputPrimitives)
+	if !info.NV() {
+		table.AddRow("maxPreferredTaskWorkGroupInvocations", info.MaxPreferredTaskWorkGroupInvocations)
+		table.AddRow("maxPreferredMeshWorkGroupInvocations", info.MaxPreferredMeshWorkGroupInvocations)
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
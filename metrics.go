@@ -0,0 +1,67 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// memoryMetrics are the gauges written by WriteMemoryMetrics, in order.
+var memoryMetrics = []struct {
+	name string
+	help string
+}{
+	{"vulkan_memory_heap_size_bytes", "Size of the memory heap."},
+	{"vulkan_memory_heap_budget_bytes", "Memory the process can use from the heap, from VK_EXT_memory_budget."},
+	{"vulkan_memory_heap_usage_bytes", "Memory the process uses from the heap, from VK_EXT_memory_budget."},
+}
+
+// WriteMemoryMetrics writes the size, budget and usage of every memory
+// heap of every GPU to w in the Prometheus text format, labeled by device
+// name, device index and heap index. The budget is queried on every call.
+// GPUs without VK_EXT_memory_budget only get the size gauge.
+func WriteMemoryMetrics(w io.Writer, v *VulkanDeviceInfo) error {
+	// samples holds the lines of each metric of memoryMetrics, which must
+	// be grouped under their HELP and TYPE lines.
+	samples := make([][]string, len(memoryMetrics))
+	for gpuIndex, gpu := range v.gpuDevices {
+		gpuProperties := getDeviceProperties(gpu)
+		name := vk.ToString(gpuProperties.DeviceName[:])
+		memoryProperties := GetMemoryProperties(gpu)
+		// budget is nil for GPUs without VK_EXT_memory_budget.
+		budget, _ := GetMemoryBudget(v, gpuIndex)
+		for i := uint32(0); i < memoryProperties.MemoryHeapCount; i++ {
+			labels := fmt.Sprintf(`device="%s",device_index="%d",heap_index="%d"`, escapeLabelValue(name), gpuIndex, i)
+			samples[0] = append(samples[0], fmt.Sprintf("%s{%s} %d", memoryMetrics[0].name, labels, memoryProperties.MemoryHeaps[i].Size))
+			if budget != nil {
+				samples[1] = append(samples[1], fmt.Sprintf("%s{%s} %d", memoryMetrics[1].name, labels, budget[i].Budget))
+				samples[2] = append(samples[2], fmt.Sprintf("%s{%s} %d", memoryMetrics[2].name, labels, budget[i].Usage))
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, metric := range memoryMetrics {
+		if len(samples[i]) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "# HELP %s %s\n", metric.name, metric.help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", metric.name)
+		for _, sample := range samples[i] {
+			b.WriteString(sample)
+			b.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// labelValueEscaper escapes backslashes, quotes and newlines in label
+// values, as the Prometheus text format requires.
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(s string) string {
+	return labelValueEscaper.Replace(s)
+}
@@ -0,0 +1,86 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"time"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// DefaultOneShotTimeout is how long SubmitOneShot waits for the GPU.
+const DefaultOneShotTimeout = 10 * time.Second
+
+// SubmitOneShot records a primary command buffer from pool with fn,
+// submits it to queue and waits up to DefaultOneShotTimeout for it to
+// complete, for setup work such as copies and layout transitions. The
+// command buffer is freed before returning. fn runs with v's read lock
+// held, so it must only record commands and not call functions taking v.
+func SubmitOneShot(v *VulkanDeviceInfo, queue vk.Queue, pool vk.CommandPool, fn func(vk.CommandBuffer)) error {
+	return SubmitOneShotTimeout(v, queue, pool, DefaultOneShotTimeout, fn)
+}
+
+// SubmitOneShotTimeout is SubmitOneShot waiting up to timeout.
+func SubmitOneShotTimeout(v *VulkanDeviceInfo, queue vk.Queue, pool vk.CommandPool, timeout time.Duration, fn func(vk.CommandBuffer)) error {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return ErrNoDevice
+	}
+	allocateInfo := &vk.CommandBufferAllocateInfo{
+		SType:              vk.StructureTypeCommandBufferAllocateInfo,
+		CommandPool:        pool,
+		Level:              vk.CommandBufferLevelPrimary,
+		CommandBufferCount: 1,
+	}
+	buffers := make([]vk.CommandBuffer, 1)
+	if err := vk.Error(vk.AllocateCommandBuffers(v.device, allocateInfo, buffers)); err != nil {
+		err = fmt.Errorf("vkAllocateCommandBuffers failed with %s", err)
+		return err
+	}
+	defer vk.FreeCommandBuffers(v.device, pool, 1, buffers)
+	commandBuffer := buffers[0]
+
+	beginInfo := &vk.CommandBufferBeginInfo{
+		SType: vk.StructureTypeCommandBufferBeginInfo,
+		Flags: vk.CommandBufferUsageFlags(vk.CommandBufferUsageOneTimeSubmitBit),
+	}
+	if err := vk.Error(vk.BeginCommandBuffer(commandBuffer, beginInfo)); err != nil {
+		err = fmt.Errorf("vkBeginCommandBuffer failed with %s", err)
+		return err
+	}
+	fn(commandBuffer)
+	if err := vk.Error(vk.EndCommandBuffer(commandBuffer)); err != nil {
+		err = fmt.Errorf("vkEndCommandBuffer failed with %s", err)
+		return err
+	}
+
+	fenceInfo := &vk.FenceCreateInfo{SType: vk.StructureTypeFenceCreateInfo}
+	var fence vk.Fence
+	if err := vk.Error(vk.CreateFence(v.device, fenceInfo, v.allocator, &fence)); err != nil {
+		err = fmt.Errorf("vkCreateFence failed with %s", err)
+		return err
+	}
+	defer vk.DestroyFence(v.device, fence, v.allocator)
+
+	submitInfo := []vk.SubmitInfo{{
+		SType:              vk.StructureTypeSubmitInfo,
+		CommandBufferCount: 1,
+		PCommandBuffers:    buffers,
+	}}
+	if err := vk.Error(vk.QueueSubmit(queue, 1, submitInfo, fence)); err != nil {
+		err = fmt.Errorf("vkQueueSubmit failed with %s", err)
+		return err
+	}
+	ret := vk.WaitForFences(v.device, 1, []vk.Fence{fence}, vk.True, uint64(timeout.Nanoseconds()))
+	if ret == vk.Timeout {
+		// The command buffer can't be freed while the GPU may still use it.
+		vk.QueueWaitIdle(queue)
+		err := fmt.Errorf("%w: vkWaitForFences after %s", ErrTimeout, timeout)
+		return err
+	}
+	if err := vk.Error(ret); err != nil {
+		err = fmt.Errorf("vkWaitForFences failed with %s", err)
+		return err
+	}
+	return nil
+}
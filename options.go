@@ -0,0 +1,200 @@
+package vulkandevice
+
+import (
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// deviceConfig collects the settings applied by Options before device creation.
+type deviceConfig struct {
+	instanceExtensions []string
+	deviceExtensions   []string
+	layers             []string
+	appInfo            *vk.ApplicationInfo
+	allocator          *vk.AllocationCallbacks
+	queueFlags         vk.QueueFlags
+	queueFamilies      []QueueFamilyRequest
+	queues             []queueRequest
+	selector           DeviceSelector
+	gpuIndex           int
+	deviceName         string
+	vendorID           uint32
+	validation         bool
+	logFunc            LogFunc
+	messengerSeverity  vk.DebugUtilsMessageSeverityFlags
+	messengerTypes     vk.DebugUtilsMessageTypeFlags
+	debugCallback      DebugCallback
+	logger             Logger
+	noDevice           bool
+	timelineSemaphores bool
+	displays           bool
+}
+
+// Option customizes how NewVulkanDevice creates the device.
+type Option func(*deviceConfig)
+
+// DeviceSelector picks the physical device to use from the enumerated GPUs.
+type DeviceSelector func([]vk.PhysicalDevice) vk.PhysicalDevice
+
+// WithInstanceExtensions enables extensions on the instance in addition to
+// the ones NewVulkanDevice needs. NewVulkanDevice fails with
+// ErrExtensionNotSupported if the loader lacks any of them.
+func WithInstanceExtensions(extensions ...string) Option {
+	return func(c *deviceConfig) {
+		c.instanceExtensions = appendNulTerminated(c.instanceExtensions, extensions)
+	}
+}
+
+// WithDeviceExtensions enables extensions on the logical device in addition
+// to VK_KHR_swapchain. NewVulkanDevice fails with ErrExtensionNotSupported,
+// before creating the device, if the GPU lacks any of them.
+func WithDeviceExtensions(extensions ...string) Option {
+	return func(c *deviceConfig) {
+		c.deviceExtensions = appendNulTerminated(c.deviceExtensions, extensions)
+	}
+}
+
+// WithLayers enables instance layers. NewVulkanDevice fails with
+// ErrLayerNotAvailable if any of them isn't installed.
+func WithLayers(layers ...string) Option {
+	return func(c *deviceConfig) {
+		c.layers = appendNulTerminated(c.layers, layers)
+	}
+}
+
+// WithAppInfo creates the instance with appInfo instead of the one passed
+// to NewVulkanDevice, which may then be nil.
+func WithAppInfo(appInfo *vk.ApplicationInfo) Option {
+	return func(c *deviceConfig) {
+		c.appInfo = appInfo
+	}
+}
+
+// WithQueue asks for count queues from the first queue family supporting
+// all of flags, with the given priorities. If priorities is nil every
+// queue gets priority 1.0. Queues asked from the same family by several
+// WithQueue and WithQueueFamilies options are created together. Retrieve
+// the queues with GetQueue.
+func WithQueue(flags vk.QueueFlags, count int, priorities []float32) Option {
+	return func(c *deviceConfig) {
+		c.queues = append(c.queues, queueRequest{
+			flags:      flags,
+			count:      count,
+			priorities: append([]float32(nil), priorities...),
+		})
+	}
+}
+
+// WithoutLogicalDevice makes NewVulkanDevice stop after enumerating and
+// selecting the GPUs, without calling vkCreateDevice. Everything reported
+// from the instance and physical devices still works; queues, swapchains and
+// other device objects are unavailable.
+func WithoutLogicalDevice() Option {
+	return func(c *deviceConfig) {
+		c.noDevice = true
+	}
+}
+
+// WithAllocator makes every Vulkan create and destroy call use allocator.
+func WithAllocator(allocator *vk.AllocationCallbacks) Option {
+	return func(c *deviceConfig) {
+		c.allocator = allocator
+	}
+}
+
+// WithQueueFlags sets the capabilities the device queue family must have.
+// The default is GRAPHICS|COMPUTE.
+func WithQueueFlags(flags vk.QueueFlags) Option {
+	return func(c *deviceConfig) {
+		c.queueFlags = flags
+	}
+}
+
+// WithQueueFamilies makes NewVulkanDevice create the device with one queue
+// per priority in each of families, instead of a single queue from the first
+// family matching WithQueueFlags. QueueFamilyIndex reports the first
+// family. Retrieve the queues with GetQueue.
+func WithQueueFamilies(families []QueueFamilyRequest) Option {
+	return func(c *deviceConfig) {
+		c.queueFamilies = append([]QueueFamilyRequest(nil), families...)
+	}
+}
+
+// WithDeviceSelector makes NewVulkanDevice use the device chosen by selector
+// instead of the first one the driver enumerates.
+func WithDeviceSelector(selector DeviceSelector) Option {
+	return func(c *deviceConfig) {
+		c.selector = selector
+	}
+}
+
+// WithPhysicalDeviceIndex makes NewVulkanDevice use the GPU at index in
+// enumeration order. It takes precedence over WithDeviceSelector.
+func WithPhysicalDeviceIndex(index int) Option {
+	return func(c *deviceConfig) {
+		c.gpuIndex = index
+	}
+}
+
+// WithDeviceName makes NewVulkanDevice use the GPU whose name contains
+// substr, ignoring case. If several GPUs match, the one with the highest
+// API version is used. It takes precedence over WithDeviceSelector.
+func WithDeviceName(substr string) Option {
+	return func(c *deviceConfig) {
+		c.deviceName = substr
+	}
+}
+
+// WithVendorID makes NewVulkanDevice use a GPU with the given PCI vendor ID,
+// such as VendorIDNVIDIA. If several GPUs match, the device selector picks
+// among them, defaulting to PreferDiscreteGPU.
+func WithVendorID(vendorID uint32) Option {
+	return func(c *deviceConfig) {
+		c.vendorID = vendorID
+	}
+}
+
+// PreferDiscreteGPU selects the first discrete GPU, falling back to an
+// integrated, virtual, then CPU device.
+func PreferDiscreteGPU(gpus []vk.PhysicalDevice) vk.PhysicalDevice {
+	return selectByScore(gpus, deviceTypeTiers)
+}
+
+// PreferIntegratedGPU selects the first integrated GPU, falling back to a
+// discrete, virtual, then CPU device.
+func PreferIntegratedGPU(gpus []vk.PhysicalDevice) vk.PhysicalDevice {
+	return selectByScore(gpus, map[vk.PhysicalDeviceType]int64{
+		vk.PhysicalDeviceTypeIntegratedGpu: 4,
+		vk.PhysicalDeviceTypeDiscreteGpu:   3,
+		vk.PhysicalDeviceTypeVirtualGpu:    2,
+		vk.PhysicalDeviceTypeCpu:           1,
+	})
+}
+
+// OnlyDeviceType returns a DeviceSelector choosing the first GPU of type t.
+// It selects nil, failing NewVulkanDevice with ErrDeviceNotFound, if there
+// is none.
+func OnlyDeviceType(t vk.PhysicalDeviceType) DeviceSelector {
+	return func(gpus []vk.PhysicalDevice) vk.PhysicalDevice {
+		for _, gpu := range gpus {
+			if getDeviceProperties(gpu).DeviceType == t {
+				return gpu
+			}
+		}
+		return nil
+	}
+}
+
+// selectByScore returns the first device with the highest score for its type.
+func selectByScore(gpus []vk.PhysicalDevice, scores map[vk.PhysicalDeviceType]int64) vk.PhysicalDevice {
+	if len(gpus) == 0 {
+		return nil
+	}
+	best, bestScore := gpus[0], int64(-1)
+	for _, gpu := range gpus {
+		gpuProperties := getDeviceProperties(gpu)
+		if score := scores[gpuProperties.DeviceType]; score > bestScore {
+			best, bestScore = gpu, score
+		}
+	}
+	return best
+}
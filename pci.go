@@ -0,0 +1,43 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// structureTypePhysicalDevicePCIBusInfoProperties is the structure type of
+// VkPhysicalDevicePCIBusInfoPropertiesEXT.
+const structureTypePhysicalDevicePCIBusInfoProperties vk.StructureType = 1000212000
+
+// physicalDevicePCIBusInfoProperties has the C layout of
+// VkPhysicalDevicePCIBusInfoPropertiesEXT.
+type physicalDevicePCIBusInfoProperties struct {
+	chainHeader
+	PCIDomain   uint32
+	PCIBus      uint32
+	PCIDevice   uint32
+	PCIFunction uint32
+}
+
+// getPCIBusInfo queries the PCI address of the GPU at gpuIndex. ok is false
+// if the GPU doesn't support VK_EXT_pci_bus_info or properties2 queries are
+// unavailable.
+func getPCIBusInfo(v *VulkanDeviceInfo, gpuIndex int) (pci physicalDevicePCIBusInfoProperties, ok bool) {
+	gpu := v.gpuDevices[gpuIndex]
+	extensions, err := EnumerateDeviceExtensions(gpu)
+	if err != nil || !hasExtension(extensions, "VK_EXT_pci_bus_info") {
+		return pci, false
+	}
+	pci.SType = structureTypePhysicalDevicePCIBusInfoProperties
+	var chain structChain
+	chain.add(unsafe.Pointer(&pci), unsafe.Sizeof(pci))
+	return pci, queryProperties2(v, gpu, &chain)
+}
+
+// formatPCIAddress renders a PCI address in domain:bus:device.function
+// (BDF) notation, such as "0000:65:00.0".
+func formatPCIAddress(pci physicalDevicePCIBusInfoProperties) string {
+	return fmt.Sprintf("%04x:%02x:%02x.%x", pci.PCIDomain, pci.PCIBus, pci.PCIDevice, pci.PCIFunction)
+}
@@ -0,0 +1,136 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// ErrIncompletePipeline is returned by ComputePipelineBuilder.Build when the
+// shader or the layout wasn't set.
+var ErrIncompletePipeline = errors.New("incomplete pipeline description")
+
+// ComputePipelineBuilder describes a compute pipeline. Set the shader and
+// the layout, then call Build:
+//
+//	pipeline, err := new(ComputePipelineBuilder).
+//		SetShader(module, "main").
+//		SetLayout(layout).
+//		Build(v, vk.NullPipelineCache)
+type ComputePipelineBuilder struct {
+	module         vk.ShaderModule
+	entryPoint     string
+	layout         vk.PipelineLayout
+	specialization *vk.SpecializationInfo
+}
+
+// SetShader sets the compute shader module and the name of its entry
+// point, such as "main".
+func (b *ComputePipelineBuilder) SetShader(mod vk.ShaderModule, entryPoint string) *ComputePipelineBuilder {
+	b.module = mod
+	b.entryPoint = entryPoint
+	return b
+}
+
+// SetLayout sets the pipeline layout, from CreatePipelineLayout.
+func (b *ComputePipelineBuilder) SetLayout(layout vk.PipelineLayout) *ComputePipelineBuilder {
+	b.layout = layout
+	return b
+}
+
+// SetSpecializationInfo sets the specialization constants of the shader,
+// or clears them if info is nil. info.PData is passed to Vulkan as is, so
+// it must point to C memory.
+func (b *ComputePipelineBuilder) SetSpecializationInfo(info *vk.SpecializationInfo) *ComputePipelineBuilder {
+	b.specialization = info
+	return b
+}
+
+// Build creates the compute pipeline on v's device, using cache if it isn't
+// vk.NullPipelineCache. Destroy it with vk.DestroyPipeline.
+func (b *ComputePipelineBuilder) Build(v *VulkanDeviceInfo, cache vk.PipelineCache) (vk.Pipeline, error) {
+	if b.module == vk.NullShaderModule || b.entryPoint == "" {
+		err := fmt.Errorf("%w: no compute shader", ErrIncompletePipeline)
+		return vk.NullPipeline, err
+	}
+	if b.layout == vk.NullPipelineLayout {
+		err := fmt.Errorf("%w: no pipeline layout", ErrIncompletePipeline)
+		return vk.NullPipeline, err
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return vk.NullPipeline, ErrNoDevice
+	}
+	entryPoint := b.entryPoint
+	if !strings.HasSuffix(entryPoint, "\x00") {
+		entryPoint += "\x00"
+	}
+	stage := vk.PipelineShaderStageCreateInfo{
+		SType:  vk.StructureTypePipelineShaderStageCreateInfo,
+		Stage:  vk.ShaderStageComputeBit,
+		Module: b.module,
+		PName:  entryPoint,
+	}
+	if b.specialization != nil {
+		stage.PSpecializationInfo = []vk.SpecializationInfo{*b.specialization}
+	}
+	createInfos := []vk.ComputePipelineCreateInfo{{
+		SType:             vk.StructureTypeComputePipelineCreateInfo,
+		Stage:             stage,
+		Layout:            b.layout,
+		BasePipelineIndex: -1,
+	}}
+	pipelines := make([]vk.Pipeline, 1)
+	if err := vk.Error(vk.CreateComputePipelines(v.device, cache, 1, createInfos, v.allocator, pipelines)); err != nil {
+		err = fmt.Errorf("vkCreateComputePipelines failed with %s", err)
+		return vk.NullPipeline, err
+	}
+	return pipelines[0], nil
+}
+
+// CreateDescriptorSetLayout creates a descriptor set layout with bindings.
+// Destroy it with vk.DestroyDescriptorSetLayout.
+func CreateDescriptorSetLayout(v *VulkanDeviceInfo, bindings []vk.DescriptorSetLayoutBinding) (vk.DescriptorSetLayout, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return vk.NullDescriptorSetLayout, ErrNoDevice
+	}
+	createInfo := &vk.DescriptorSetLayoutCreateInfo{
+		SType:        vk.StructureTypeDescriptorSetLayoutCreateInfo,
+		BindingCount: uint32(len(bindings)),
+		PBindings:    bindings,
+	}
+	var layout vk.DescriptorSetLayout
+	if err := vk.Error(vk.CreateDescriptorSetLayout(v.device, createInfo, v.allocator, &layout)); err != nil {
+		err = fmt.Errorf("vkCreateDescriptorSetLayout failed with %s", err)
+		return vk.NullDescriptorSetLayout, err
+	}
+	return layout, nil
+}
+
+// CreatePipelineLayout creates a pipeline layout with setLayouts, in set
+// number order, and pushRanges. Destroy it with vk.DestroyPipelineLayout.
+func CreatePipelineLayout(v *VulkanDeviceInfo, setLayouts []vk.DescriptorSetLayout, pushRanges []vk.PushConstantRange) (vk.PipelineLayout, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return vk.NullPipelineLayout, ErrNoDevice
+	}
+	createInfo := &vk.PipelineLayoutCreateInfo{
+		SType:                  vk.StructureTypePipelineLayoutCreateInfo,
+		SetLayoutCount:         uint32(len(setLayouts)),
+		PSetLayouts:            setLayouts,
+		PushConstantRangeCount: uint32(len(pushRanges)),
+		PPushConstantRanges:    pushRanges,
+	}
+	var layout vk.PipelineLayout
+	if err := vk.Error(vk.CreatePipelineLayout(v.device, createInfo, v.allocator, &layout)); err != nil {
+		err = fmt.Errorf("vkCreatePipelineLayout failed with %s", err)
+		return vk.NullPipelineLayout, err
+	}
+	return layout, nil
+}
@@ -0,0 +1,198 @@
+package vulkandevice
+
+/*
+#include <stdlib.h>
+*/
+import "C"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// ErrStalePipelineCache is returned when a pipeline cache file was saved
+// on another GPU or driver version.
+var ErrStalePipelineCache = errors.New("pipeline cache from another device or driver")
+
+// pipelineCacheMagic starts the files written by SavePipelineCacheToFile.
+var pipelineCacheMagic = [4]byte{'V', 'K', 'P', 'C'}
+
+// pipelineCacheFileVersion is the version of pipelineCacheFileHeader.
+const pipelineCacheFileVersion = 1
+
+// pipelineCacheFileHeader precedes the cache data in the files written by
+// SavePipelineCacheToFile. It is written little-endian.
+type pipelineCacheFileHeader struct {
+	Magic   [4]byte
+	Version uint32
+	// DeviceUUID is the device UUID, or the pipeline cache UUID on GPUs
+	// without VkPhysicalDeviceIDProperties.
+	DeviceUUID    [vk.UuidSize]byte
+	DriverVersion uint32
+	DataSize      uint64
+}
+
+// CreatePipelineCache creates a pipeline cache on v's device, seeded with
+// initialData from SerializePipelineCache if it isn't empty. The driver
+// ignores data it can't use. Destroy it with vk.DestroyPipelineCache.
+func CreatePipelineCache(v *VulkanDeviceInfo, initialData []byte) (vk.PipelineCache, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return createPipelineCache(v, initialData)
+}
+
+// createPipelineCache is CreatePipelineCache for callers that hold v.mu.
+func createPipelineCache(v *VulkanDeviceInfo, initialData []byte) (vk.PipelineCache, error) {
+	if v.device == nil {
+		return vk.NullPipelineCache, ErrNoDevice
+	}
+	createInfo := &vk.PipelineCacheCreateInfo{
+		SType: vk.StructureTypePipelineCacheCreateInfo,
+	}
+	if len(initialData) > 0 {
+		// The create info is copied to C memory by the bindings, so the
+		// data must not be Go memory.
+		data := C.CBytes(initialData)
+		defer C.free(data)
+		createInfo.InitialDataSize = uint(len(initialData))
+		createInfo.PInitialData = data
+	}
+	var cache vk.PipelineCache
+	if err := vk.Error(vk.CreatePipelineCache(v.device, createInfo, v.allocator, &cache)); err != nil {
+		err = fmt.Errorf("vkCreatePipelineCache failed with %s", err)
+		return vk.NullPipelineCache, err
+	}
+	return cache, nil
+}
+
+// SerializePipelineCache returns the contents of cache, for
+// CreatePipelineCache in a later run.
+func SerializePipelineCache(v *VulkanDeviceInfo, cache vk.PipelineCache) ([]byte, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return serializePipelineCache(v, cache)
+}
+
+// serializePipelineCache is SerializePipelineCache for callers that hold
+// v.mu.
+func serializePipelineCache(v *VulkanDeviceInfo, cache vk.PipelineCache) ([]byte, error) {
+	if v.device == nil {
+		return nil, ErrNoDevice
+	}
+	var size uint
+	if err := vk.Error(vk.GetPipelineCacheData(v.device, cache, &size, nil)); err != nil {
+		err = fmt.Errorf("vkGetPipelineCacheData failed with %s", err)
+		return nil, err
+	}
+	if size == 0 {
+		return nil, nil
+	}
+	data := make([]byte, size)
+	if err := vk.Error(vk.GetPipelineCacheData(v.device, cache, &size, unsafe.Pointer(&data[0]))); err != nil {
+		err = fmt.Errorf("vkGetPipelineCacheData failed with %s", err)
+		return nil, err
+	}
+	return data[:size], nil
+}
+
+// SavePipelineCacheToFile writes the contents of cache to path, after a
+// header identifying the GPU and driver version so LoadPipelineCacheFromFile
+// can reject the file after a driver update.
+func SavePipelineCacheToFile(v *VulkanDeviceInfo, cache vk.PipelineCache, path string) error {
+	v.mu.RLock()
+	data, err := serializePipelineCache(v, cache)
+	if err != nil {
+		v.mu.RUnlock()
+		return err
+	}
+	header := newPipelineCacheFileHeader(v)
+	v.mu.RUnlock()
+	header.DataSize = uint64(len(data))
+	var buf bytes.Buffer
+	if err := binary.Write(&buf, binary.LittleEndian, &header); err != nil {
+		return err
+	}
+	buf.Write(data)
+	return os.WriteFile(path, buf.Bytes(), 0o644)
+}
+
+// LoadPipelineCacheFromFile creates a pipeline cache seeded with the file
+// at path written by SavePipelineCacheToFile. If the file doesn't exist,
+// or was saved on another GPU or driver version, an empty cache is created
+// instead, so the caller can always save it again on exit.
+func LoadPipelineCacheFromFile(v *VulkanDeviceInfo, path string) (vk.PipelineCache, error) {
+	file, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return CreatePipelineCache(v, nil)
+	} else if err != nil {
+		return vk.NullPipelineCache, err
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return vk.NullPipelineCache, ErrNoDevice
+	}
+	data, err := pipelineCacheFileData(v, file)
+	if errors.Is(err, ErrStalePipelineCache) {
+		v.logger.Info("ignoring pipeline cache", "path", path, "reason", err)
+		return createPipelineCache(v, nil)
+	} else if err != nil {
+		err = fmt.Errorf("%s: %s", path, err)
+		return vk.NullPipelineCache, err
+	}
+	return createPipelineCache(v, data)
+}
+
+// pipelineCacheFileData checks the header of file against the selected
+// GPU and returns the cache data following it. The caller must hold v.mu.
+func pipelineCacheFileData(v *VulkanDeviceInfo, file []byte) ([]byte, error) {
+	var header pipelineCacheFileHeader
+	r := bytes.NewReader(file)
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil || header.Magic != pipelineCacheMagic {
+		err := fmt.Errorf("not a pipeline cache file")
+		return nil, err
+	}
+	if header.Version != pipelineCacheFileVersion {
+		err := fmt.Errorf("%w: file version %d", ErrStalePipelineCache, header.Version)
+		return nil, err
+	}
+	want := newPipelineCacheFileHeader(v)
+	if header.DeviceUUID != want.DeviceUUID {
+		err := fmt.Errorf("%w: device UUID %s, want %s", ErrStalePipelineCache,
+			formatUUID(header.DeviceUUID), formatUUID(want.DeviceUUID))
+		return nil, err
+	}
+	if header.DriverVersion != want.DriverVersion {
+		err := fmt.Errorf("%w: driver version %#x, want %#x", ErrStalePipelineCache, header.DriverVersion, want.DriverVersion)
+		return nil, err
+	}
+	data := file[len(file)-r.Len():]
+	if uint64(len(data)) != header.DataSize {
+		err := fmt.Errorf("truncated pipeline cache file: %d bytes of data, want %d", len(data), header.DataSize)
+		return nil, err
+	}
+	return data, nil
+}
+
+// newPipelineCacheFileHeader returns the header identifying the selected
+// GPU and its driver, without DataSize. The caller must hold v.mu and v
+// must have a device.
+func newPipelineCacheFileHeader(v *VulkanDeviceInfo) pipelineCacheFileHeader {
+	gpuProperties := getDeviceProperties(v.gpuDevices[v.gpuIndex])
+	header := pipelineCacheFileHeader{
+		Magic:         pipelineCacheMagic,
+		Version:       pipelineCacheFileVersion,
+		DeviceUUID:    gpuProperties.PipelineCacheUUID,
+		DriverVersion: gpuProperties.DriverVersion,
+	}
+	if ids, ok := getIDProperties(v, v.gpuIndex); ok {
+		header.DeviceUUID = ids.DeviceUUID
+	}
+	return header
+}
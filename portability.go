@@ -0,0 +1,27 @@
+package vulkandevice
+
+import (
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// portabilityEnumerationExtensionName makes the loader enumerate
+// non-conformant implementations such as MoltenVK, which are hidden unless
+// the instance sets instanceCreateEnumeratePortability.
+const portabilityEnumerationExtensionName = "VK_KHR_portability_enumeration"
+
+// portabilitySubsetExtensionName is advertised by implementations layered
+// over another API, such as MoltenVK over Metal, and must be enabled on
+// their devices.
+const portabilitySubsetExtensionName = "VK_KHR_portability_subset"
+
+// instanceCreateEnumeratePortability is
+// VK_INSTANCE_CREATE_ENUMERATE_PORTABILITY_BIT_KHR.
+const instanceCreateEnumeratePortability vk.InstanceCreateFlags = 0x1
+
+// IsPortabilitySubset reports whether gpu is a portability subset
+// implementation, such as MoltenVK, which translates Vulkan to another API
+// and may not support every core feature.
+func IsPortabilitySubset(gpu vk.PhysicalDevice) bool {
+	extensions, err := EnumerateDeviceExtensions(gpu)
+	return err == nil && hasExtension(extensions, portabilitySubsetExtensionName)
+}
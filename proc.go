@@ -0,0 +1,92 @@
+package vulkandevice
+
+/*
+#include <stdint.h>
+#include <stddef.h>
+#include <stdlib.h>
+
+typedef void *(*get_instance_proc_addr_t)(void *instance, const char *name);
+typedef int32_t (*enumerate_instance_version_t)(uint32_t *version);
+typedef void (*physical_device_query_t)(void *gpu, void *out);
+typedef void (*physical_device_info_query_t)(void *gpu, const void *info, void *out);
+
+// vgo_vkGetInstanceProcAddr is loaded by vk.Init in the vulkan-go binding.
+extern get_instance_proc_addr_t vgo_vkGetInstanceProcAddr;
+
+// lookup_instance_proc returns the entry point name of instance, or NULL
+// if it or the loader is missing. It isn't static so the preambles of the
+// other files can declare and share it.
+void *lookup_instance_proc(void *instance, const char *name) {
+	if (vgo_vkGetInstanceProcAddr == NULL) {
+		return NULL;
+	}
+	return vgo_vkGetInstanceProcAddr(instance, name);
+}
+
+static int32_t enumerate_instance_version(uint32_t *version, int *found) {
+	enumerate_instance_version_t fn = (enumerate_instance_version_t)
+		lookup_instance_proc(NULL, "vkEnumerateInstanceVersion");
+	*found = fn != NULL;
+	if (fn == NULL) {
+		return 0;
+	}
+	return fn(version);
+}
+
+static int physical_device_query(void *instance, const char *name, void *gpu, void *out) {
+	physical_device_query_t fn = (physical_device_query_t)lookup_instance_proc(instance, name);
+	if (fn == NULL) {
+		return 0;
+	}
+	fn(gpu, out);
+	return 1;
+}
+
+static int physical_device_info_query(void *instance, const char *name, void *gpu, const void *info, void *out) {
+	physical_device_info_query_t fn = (physical_device_info_query_t)lookup_instance_proc(instance, name);
+	if (fn == NULL) {
+		return 0;
+	}
+	fn(gpu, info, out);
+	return 1;
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// This file calls Vulkan entry points the vulkan-go bindings do not wrap,
+// looking them up through the loader the bindings were initialized with.
+
+// enumerateInstanceVersion calls vkEnumerateInstanceVersion. found is false
+// if the loader predates it.
+func enumerateInstanceVersion() (version uint32, ret vk.Result, found bool) {
+	var cVersion C.uint32_t
+	var cFound C.int
+	ret = vk.Result(C.enumerate_instance_version(&cVersion, &cFound))
+	return uint32(cVersion), ret, cFound != 0
+}
+
+// physicalDeviceQuery calls the instance entry point name, which must have
+// the signature void(VkPhysicalDevice, T*), such as
+// vkGetPhysicalDeviceFeatures2. out must point to C memory. It returns
+// false if the instance does not provide the entry point.
+func physicalDeviceQuery(instance vk.Instance, name string, gpu vk.PhysicalDevice, out unsafe.Pointer) bool {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	return C.physical_device_query(unsafe.Pointer(instance), cName, unsafe.Pointer(gpu), out) != 0
+}
+
+// physicalDeviceInfoQuery is physicalDeviceQuery for entry points with the
+// signature void(VkPhysicalDevice, const I*, T*), such as
+// vkGetPhysicalDeviceExternalBufferProperties. info and out must point to
+// C memory.
+func physicalDeviceInfoQuery(instance vk.Instance, name string, gpu vk.PhysicalDevice, info, out unsafe.Pointer) bool {
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	return C.physical_device_info_query(unsafe.Pointer(instance), cName, unsafe.Pointer(gpu), info, out) != 0
+}
@@ -0,0 +1,142 @@
+package vulkandevice
+
+import (
+	"errors"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// properties2ExtensionName provides the *2 query entry points on Vulkan 1.0
+// instances.
+const properties2ExtensionName = "VK_KHR_get_physical_device_properties2"
+
+// physicalDeviceProperties2 has the C layout of VkPhysicalDeviceProperties2.
+// The core properties are only filled in by the driver, so they are kept as
+// bytes sized for the 64-bit VkPhysicalDeviceProperties and decoded with
+// the bindings afterwards.
+type physicalDeviceProperties2 struct {
+	chainHeader
+	Properties [824]byte
+}
+
+// physicalDeviceMemoryProperties2 has the C layout of
+// VkPhysicalDeviceMemoryProperties2, with the core properties kept as bytes
+// like physicalDeviceProperties2.
+type physicalDeviceMemoryProperties2 struct {
+	chainHeader
+	MemoryProperties [520]byte
+}
+
+// ErrProperties2NotSupported is returned by GetPhysicalDeviceProperties2 and
+// GetPhysicalDeviceFeatures2 when the instance is Vulkan 1.0 without
+// VK_KHR_get_physical_device_properties2.
+var ErrProperties2NotSupported = errors.New("vkGetPhysicalDeviceProperties2 requires Vulkan 1.1 or " + properties2ExtensionName)
+
+// ChainedStruct is an extension structure for the pNext chain of
+// GetPhysicalDeviceProperties2 and GetPhysicalDeviceFeatures2. Value
+// points to a Go value with the C layout of the Vulkan structure, starting
+// with sType and pNext and holding no Go pointers, and Size is its size in
+// bytes. The Vulkan binding structures carry Go-only fields and can't be
+// used.
+//
+// A bare unsafe.Pointer isn't enough: cgo forbids passing C Go memory
+// holding pointers to other Go memory, so the chain is copied into C memory
+// for the call, which needs the size of every structure.
+type ChainedStruct struct {
+	Value unsafe.Pointer
+	Size  uintptr
+}
+
+// GetPhysicalDeviceProperties2 calls vkGetPhysicalDeviceProperties2 for the
+// GPU at gpuIndex, filling in the structures of chain, linked in order
+// after VkPhysicalDeviceProperties2, and returns the core properties. The
+// returned PNext is nil; the extension structures are read through chain.
+// The caller must check that the GPU supports every structure in chain.
+//
+// It takes v and a GPU index rather than a vk.PhysicalDevice because the
+// entry point is looked up on v's instance, with the KHR suffix on Vulkan
+// 1.0 instances using VK_KHR_get_physical_device_properties2.
+func GetPhysicalDeviceProperties2(v *VulkanDeviceInfo, gpuIndex int, chain ...ChainedStruct) (vk.PhysicalDeviceProperties2, error) {
+	var c structChain
+	for _, s := range chain {
+		c.add(s.Value, s.Size)
+	}
+	properties2 := physicalDeviceProperties2{chainHeader: chainHeader{SType: vk.StructureTypePhysicalDeviceProperties2}}
+	if !query2(v, "vkGetPhysicalDeviceProperties2", v.gpuDevices[gpuIndex], unsafe.Pointer(&properties2), unsafe.Sizeof(properties2), &c) {
+		return vk.PhysicalDeviceProperties2{}, ErrProperties2NotSupported
+	}
+	properties := vk.NewPhysicalDevicePropertiesRef(unsafe.Pointer(&properties2.Properties[0]))
+	properties.Deref()
+	properties.Limits.Deref()
+	properties.SparseProperties.Deref()
+	return vk.PhysicalDeviceProperties2{
+		SType:      vk.StructureTypePhysicalDeviceProperties2,
+		Properties: *properties,
+	}, nil
+}
+
+// GetPhysicalDeviceFeatures2 is GetPhysicalDeviceProperties2 for
+// vkGetPhysicalDeviceFeatures2, returning the core features.
+func GetPhysicalDeviceFeatures2(v *VulkanDeviceInfo, gpuIndex int, chain ...ChainedStruct) (vk.PhysicalDeviceFeatures2, error) {
+	var c structChain
+	for _, s := range chain {
+		c.add(s.Value, s.Size)
+	}
+	features2 := physicalDeviceFeatures2{chainHeader: chainHeader{SType: vk.StructureTypePhysicalDeviceFeatures2}}
+	if !query2(v, "vkGetPhysicalDeviceFeatures2", v.gpuDevices[gpuIndex], unsafe.Pointer(&features2), unsafe.Sizeof(features2), &c) {
+		return vk.PhysicalDeviceFeatures2{}, ErrProperties2NotSupported
+	}
+	features := vk.NewPhysicalDeviceFeaturesRef(unsafe.Pointer(&features2.Features[0]))
+	features.Deref()
+	return vk.PhysicalDeviceFeatures2{
+		SType:    vk.StructureTypePhysicalDeviceFeatures2,
+		Features: *features,
+	}, nil
+}
+
+// properties2Name returns the name of the *2 query entry point to use on
+// v's instance, or "" if there is none.
+func (v *VulkanDeviceInfo) properties2Name(name string) string {
+	switch {
+	case v.apiVersion >= vk.MakeVersion(1, 1, 0):
+		return name
+	case v.properties2KHR:
+		return name + "KHR"
+	}
+	return ""
+}
+
+// queryProperties2 fills in the structures of chain through
+// vkGetPhysicalDeviceProperties2. It returns false if the instance can't
+// make the query; the caller must check that gpu supports every structure
+// in chain.
+func queryProperties2(v *VulkanDeviceInfo, gpu vk.PhysicalDevice, chain *structChain) bool {
+	properties2 := physicalDeviceProperties2{chainHeader: chainHeader{SType: vk.StructureTypePhysicalDeviceProperties2}}
+	return query2(v, "vkGetPhysicalDeviceProperties2", gpu, unsafe.Pointer(&properties2), unsafe.Sizeof(properties2), chain)
+}
+
+// queryMemoryProperties2 is queryProperties2 for
+// vkGetPhysicalDeviceMemoryProperties2.
+func queryMemoryProperties2(v *VulkanDeviceInfo, gpu vk.PhysicalDevice, chain *structChain) bool {
+	memoryProperties2 := physicalDeviceMemoryProperties2{chainHeader: chainHeader{SType: vk.StructureTypePhysicalDeviceMemoryProperties2}}
+	return query2(v, "vkGetPhysicalDeviceMemoryProperties2", gpu, unsafe.Pointer(&memoryProperties2), unsafe.Sizeof(memoryProperties2), chain)
+}
+
+// query2 calls the *2 query name with head, of the given size, followed by
+// the structures of chain.
+func query2(v *VulkanDeviceInfo, name string, gpu vk.PhysicalDevice, head unsafe.Pointer, size uintptr, chain *structChain) bool {
+	name = v.properties2Name(name)
+	if name == "" {
+		return false
+	}
+	var full structChain
+	full.add(head, size)
+	full.links = append(full.links, chain.links...)
+	defer full.free()
+	if !physicalDeviceQuery(v.instance, name, gpu, full.link()) {
+		return false
+	}
+	full.read()
+	return true
+}
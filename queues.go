@@ -0,0 +1,186 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// GetQueueFamilyProperties returns the queue families supported by gpu.
+func GetQueueFamilyProperties(gpu vk.PhysicalDevice) []vk.QueueFamilyProperties {
+	var familyCount uint32
+	vk.GetPhysicalDeviceQueueFamilyProperties(gpu, &familyCount, nil)
+	if familyCount == 0 {
+		return nil
+	}
+	families := make([]vk.QueueFamilyProperties, familyCount)
+	vk.GetPhysicalDeviceQueueFamilyProperties(gpu, &familyCount, families)
+	for i := range families {
+		families[i].Deref()
+		families[i].MinImageTransferGranularity.Deref()
+	}
+	return families
+}
+
+// findQueueFamily returns the index of the first family supporting all of flags.
+func findQueueFamily(families []vk.QueueFamilyProperties, flags vk.QueueFlags) (uint32, error) {
+	for i, family := range families {
+		if family.QueueCount > 0 && family.QueueFlags&flags == flags {
+			return uint32(i), nil
+		}
+	}
+	err := fmt.Errorf("findQueueFamily: no queue family supports %s", queueFlags(flags))
+	return 0, err
+}
+
+// ErrNoPresentQueue is returned by FindPresentQueueFamily when no queue
+// family of the GPU can present to the surface.
+var ErrNoPresentQueue = errors.New("no queue family can present to the surface")
+
+// FindGraphicsQueueFamily returns the index of the first queue family of the
+// GPU at gpuIndex that supports graphics.
+func FindGraphicsQueueFamily(v *VulkanDeviceInfo, gpuIndex int) (uint32, error) {
+	return findQueueFamily(v.queueFamilies[gpuIndex], vk.QueueFlags(vk.QueueGraphicsBit))
+}
+
+// FindComputeQueueFamily returns the index of the first queue family of the
+// GPU at gpuIndex that supports compute.
+func FindComputeQueueFamily(v *VulkanDeviceInfo, gpuIndex int) (uint32, error) {
+	return findQueueFamily(v.queueFamilies[gpuIndex], vk.QueueFlags(vk.QueueComputeBit))
+}
+
+// FindPresentQueueFamily returns the index of the first queue family of the
+// GPU at gpuIndex that can present to surface.
+func FindPresentQueueFamily(v *VulkanDeviceInfo, gpuIndex int, surface vk.Surface) (uint32, error) {
+	gpu := v.gpuDevices[gpuIndex]
+	for i := range v.queueFamilies[gpuIndex] {
+		var supported vk.Bool32
+		err := vk.Error(vk.GetPhysicalDeviceSurfaceSupport(gpu, uint32(i), surface, &supported))
+		if err != nil {
+			err = fmt.Errorf("vkGetPhysicalDeviceSurfaceSupportKHR failed with %s", err)
+			return 0, err
+		}
+		if supported.B() {
+			return uint32(i), nil
+		}
+	}
+	return 0, ErrNoPresentQueue
+}
+
+// ErrQueueNotFound is returned by GetQueue when the device wasn't created
+// with the requested queue.
+var ErrQueueNotFound = errors.New("queue not found")
+
+// QueueFamilyRequest asks for one queue from family FamilyIndex per entry in
+// Priorities, each between 0.0 and 1.0.
+type QueueFamilyRequest struct {
+	FamilyIndex uint32
+	Priorities  []float32
+}
+
+// queueRequest is a WithQueue request, resolved to a queue family when the
+// device is created.
+type queueRequest struct {
+	flags      vk.QueueFlags
+	count      int
+	priorities []float32
+}
+
+// resolveQueueRequests merges the WithQueue requests into the
+// WithQueueFamilies ones, adding the priorities of each to the first family
+// supporting its flags.
+func resolveQueueRequests(families []vk.QueueFamilyProperties, explicit []QueueFamilyRequest, queues []queueRequest) ([]QueueFamilyRequest, error) {
+	requests := make([]QueueFamilyRequest, 0, len(explicit)+len(queues))
+	for _, request := range explicit {
+		request.Priorities = append([]float32(nil), request.Priorities...)
+		requests = append(requests, request)
+	}
+	for _, queue := range queues {
+		priorities := queue.priorities
+		if len(priorities) == 0 {
+			priorities = make([]float32, queue.count)
+			for i := range priorities {
+				priorities[i] = 1.0
+			}
+		} else if len(priorities) != queue.count {
+			err := fmt.Errorf("WithQueue: %d priorities given for %d queues", len(priorities), queue.count)
+			return nil, err
+		}
+		familyIndex, err := findQueueFamily(families, queue.flags)
+		if err != nil {
+			return nil, err
+		}
+		merged := false
+		for i := range requests {
+			if requests[i].FamilyIndex == familyIndex {
+				requests[i].Priorities = append(requests[i].Priorities, priorities...)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			requests = append(requests, QueueFamilyRequest{FamilyIndex: familyIndex, Priorities: priorities})
+		}
+	}
+	return requests, nil
+}
+
+// checkQueueFamilyRequests reports an error if a request names a family the
+// GPU doesn't have, repeats a family, or asks for more queues than the
+// family provides.
+func checkQueueFamilyRequests(families []vk.QueueFamilyProperties, requests []QueueFamilyRequest) error {
+	seen := make(map[uint32]bool, len(requests))
+	for _, request := range requests {
+		if request.FamilyIndex >= uint32(len(families)) {
+			err := fmt.Errorf("checkQueueFamilyRequests: queue family %d does not exist, the GPU has %d", request.FamilyIndex, len(families))
+			return err
+		}
+		if seen[request.FamilyIndex] {
+			err := fmt.Errorf("checkQueueFamilyRequests: queue family %d requested more than once", request.FamilyIndex)
+			return err
+		}
+		seen[request.FamilyIndex] = true
+		count := uint32(len(request.Priorities))
+		if count == 0 || count > families[request.FamilyIndex].QueueCount {
+			err := fmt.Errorf("checkQueueFamilyRequests: queue family %d provides %d queues, %d requested",
+				request.FamilyIndex, families[request.FamilyIndex].QueueCount, count)
+			return err
+		}
+	}
+	return nil
+}
+
+// PrintQueueFamilies prints the queue families of the GPU at gpuIndex.
+func PrintQueueFamilies(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Queue Families", gpuIndex))
+	table.AddHeaders("Family", "Queues", "Timestamp Bits", "Min Image Transfer Granularity", "Flags")
+	for i, family := range v.queueFamilies[gpuIndex] {
+		granularity := family.MinImageTransferGranularity
+		table.AddRow(i, family.QueueCount, family.TimestampValidBits,
+			fmt.Sprintf("%dx%dx%d", granularity.Width, granularity.Height, granularity.Depth),
+			queueFlags(family.QueueFlags))
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
+
+var queueFlagTable = []flagName{
+	{uint32(vk.QueueGraphicsBit), "GRAPHICS"},
+	{uint32(vk.QueueComputeBit), "COMPUTE"},
+	{uint32(vk.QueueTransferBit), "TRANSFER"},
+	{uint32(vk.QueueSparseBindingBit), "SPARSE_BINDING"},
+	{uint32(vk.QueueProtectedBit), "PROTECTED"},
+}
+
+func queueFlags(flags vk.QueueFlags) string {
+	return joinFlags(queueFlagNames(flags))
+}
+
+func queueFlagNames(flags vk.QueueFlags) []string {
+	return flagNames(uint32(flags), queueFlagTable)
+}
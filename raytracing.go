@@ -0,0 +1,220 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// Extensions providing hardware ray tracing.
+const (
+	accelerationStructureExtensionName = "VK_KHR_acceleration_structure"
+	rayTracingPipelineExtensionName    = "VK_KHR_ray_tracing_pipeline"
+	rayQueryExtensionName              = "VK_KHR_ray_query"
+)
+
+// Structure types of the ray tracing structures, which are newer than the
+// vulkan-go bindings.
+const (
+	structureTypePhysicalDeviceAccelerationStructureFeatures   vk.StructureType = 1000150013
+	structureTypePhysicalDeviceAccelerationStructureProperties vk.StructureType = 1000150014
+	structureTypePhysicalDeviceRayTracingPipelineFeatures      vk.StructureType = 1000347000
+	structureTypePhysicalDeviceRayTracingPipelineProperties    vk.StructureType = 1000347001
+	structureTypePhysicalDeviceRayQueryFeatures                vk.StructureType = 1000348013
+)
+
+// physicalDeviceAccelerationStructureFeatures has the C layout of
+// VkPhysicalDeviceAccelerationStructureFeaturesKHR.
+type physicalDeviceAccelerationStructureFeatures struct {
+	chainHeader
+	AccelerationStructure                                 vk.Bool32
+	AccelerationStructureCaptureReplay                    vk.Bool32
+	AccelerationStructureIndirectBuild                    vk.Bool32
+	AccelerationStructureHostCommands                     vk.Bool32
+	DescriptorBindingAccelerationStructureUpdateAfterBind vk.Bool32
+}
+
+// physicalDeviceAccelerationStructureProperties has the C layout of
+// VkPhysicalDeviceAccelerationStructurePropertiesKHR.
+type physicalDeviceAccelerationStructureProperties struct {
+	chainHeader
+	MaxGeometryCount                                           uint64
+	MaxInstanceCount                                           uint64
+	MaxPrimitiveCount                                          uint64
+	MaxPerStageDescriptorAccelerationStructures                uint32
+	MaxPerStageDescriptorUpdateAfterBindAccelerationStructures uint32
+	MaxDescriptorSetAccelerationStructures                     uint32
+	MaxDescriptorSetUpdateAfterBindAccelerationStructures      uint32
+	MinAccelerationStructureScratchOffsetAlignment             uint32
+}
+
+// physicalDeviceRayTracingPipelineFeatures has the C layout of
+// VkPhysicalDeviceRayTracingPipelineFeaturesKHR.
+type physicalDeviceRayTracingPipelineFeatures struct {
+	chainHeader
+	RayTracingPipeline                                    vk.Bool32
+	RayTracingPipelineShaderGroupHandleCaptureReplay      vk.Bool32
+	RayTracingPipelineShaderGroupHandleCaptureReplayMixed vk.Bool32
+	RayTracingPipelineTraceRaysIndirect                   vk.Bool32
+	RayTraversalPrimitiveCulling                          vk.Bool32
+}
+
+// physicalDeviceRayTracingPipelineProperties has the C layout of
+// VkPhysicalDeviceRayTracingPipelinePropertiesKHR.
+type physicalDeviceRayTracingPipelineProperties struct {
+	chainHeader
+	ShaderGroupHandleSize              uint32
+	MaxRayRecursionDepth               uint32
+	MaxShaderGroupStride               uint32
+	ShaderGroupBaseAlignment           uint32
+	ShaderGroupHandleCaptureReplaySize uint32
+	MaxRayDispatchInvocationCount      uint32
+	ShaderGroupHandleAlignment         uint32
+	MaxRayHitAttributeSize             uint32
+}
+
+// physicalDeviceRayQueryFeatures has the C layout of
+// VkPhysicalDeviceRayQueryFeaturesKHR.
+type physicalDeviceRayQueryFeatures struct {
+	chainHeader
+	RayQuery vk.Bool32
+}
+
+// RayTracingInfo holds the ray tracing support of a GPU. The limits of an
+// extension are zero when the GPU doesn't advertise it.
+type RayTracingInfo struct {
+	// AccelerationStructure, RayTracingPipeline and RayQuery are whether the
+	// GPU advertises VK_KHR_acceleration_structure,
+	// VK_KHR_ray_tracing_pipeline and VK_KHR_ray_query.
+	AccelerationStructure bool `json:"acceleration_structure"`
+	RayTracingPipeline    bool `json:"ray_tracing_pipeline"`
+	RayQuery              bool `json:"ray_query"`
+
+	MaxGeometryCount  uint64 `json:"max_geometry_count"`
+	MaxInstanceCount  uint64 `json:"max_instance_count"`
+	MaxPrimitiveCount uint64 `json:"max_primitive_count"`
+
+	MaxRayRecursionDepth  uint32 `json:"max_ray_recursion_depth"`
+	ShaderGroupHandleSize uint32 `json:"shader_group_handle_size"`
+
+	// The features, false when the GPU doesn't advertise their extension.
+	AccelerationStructureFeature        bool `json:"acceleration_structure_feature"`
+	RayTracingPipelineFeature           bool `json:"ray_tracing_pipeline_feature"`
+	RayTracingPipelineTraceRaysIndirect bool `json:"ray_tracing_pipeline_trace_rays_indirect"`
+	RayQueryFeature                     bool `json:"ray_query_feature"`
+}
+
+// Supported reports whether the GPU can trace rays at all, from ray tracing
+// pipelines or from ray queries in other shaders.
+func (info RayTracingInfo) Supported() bool {
+	return info.AccelerationStructure && (info.RayTracingPipeline || info.RayQuery)
+}
+
+// Summary answers in a few words whether and how the GPU can trace rays.
+func (info RayTracingInfo) Summary() string {
+	switch {
+	case !info.Supported():
+		return "not supported"
+	case !info.RayTracingPipeline:
+		return "ray queries only (VK_KHR_ray_query), no ray tracing pipelines"
+	case !info.RayQuery:
+		return "ray tracing pipelines only, no ray queries"
+	}
+	return "ray tracing pipelines and ray queries"
+}
+
+// GetRayTracingInfo queries the ray tracing properties and features of the
+// GPU at gpuIndex, chaining only the structures of the extensions it
+// advertises. A GPU without them gets a zero RayTracingInfo and no error.
+func GetRayTracingInfo(v *VulkanDeviceInfo, gpuIndex int) (RayTracingInfo, error) {
+	var info RayTracingInfo
+	extensions, err := EnumerateDeviceExtensions(v.gpuDevices[gpuIndex])
+	if err != nil {
+		return info, err
+	}
+	info.AccelerationStructure = hasExtension(extensions, accelerationStructureExtensionName)
+	info.RayTracingPipeline = hasExtension(extensions, rayTracingPipelineExtensionName)
+	info.RayQuery = hasExtension(extensions, rayQueryExtensionName)
+	if !info.AccelerationStructure && !info.RayTracingPipeline && !info.RayQuery {
+		return info, nil
+	}
+
+	accelerationProperties := physicalDeviceAccelerationStructureProperties{chainHeader: chainHeader{SType: structureTypePhysicalDeviceAccelerationStructureProperties}}
+	pipelineProperties := physicalDeviceRayTracingPipelineProperties{chainHeader: chainHeader{SType: structureTypePhysicalDeviceRayTracingPipelineProperties}}
+	accelerationFeatures := physicalDeviceAccelerationStructureFeatures{chainHeader: chainHeader{SType: structureTypePhysicalDeviceAccelerationStructureFeatures}}
+	pipelineFeatures := physicalDeviceRayTracingPipelineFeatures{chainHeader: chainHeader{SType: structureTypePhysicalDeviceRayTracingPipelineFeatures}}
+	queryFeatures := physicalDeviceRayQueryFeatures{chainHeader: chainHeader{SType: structureTypePhysicalDeviceRayQueryFeatures}}
+	var properties, features []ChainedStruct
+	if info.AccelerationStructure {
+		properties = append(properties, ChainedStruct{unsafe.Pointer(&accelerationProperties), unsafe.Sizeof(accelerationProperties)})
+		features = append(features, ChainedStruct{unsafe.Pointer(&accelerationFeatures), unsafe.Sizeof(accelerationFeatures)})
+	}
+	if info.RayTracingPipeline {
+		properties = append(properties, ChainedStruct{unsafe.Pointer(&pipelineProperties), unsafe.Sizeof(pipelineProperties)})
+		features = append(features, ChainedStruct{unsafe.Pointer(&pipelineFeatures), unsafe.Sizeof(pipelineFeatures)})
+	}
+	if info.RayQuery {
+		features = append(features, ChainedStruct{unsafe.Pointer(&queryFeatures), unsafe.Sizeof(queryFeatures)})
+	}
+	if len(properties) > 0 {
+		if _, err := GetPhysicalDeviceProperties2(v, gpuIndex, properties...); err != nil {
+			return info, err
+		}
+	}
+	if _, err := GetPhysicalDeviceFeatures2(v, gpuIndex, features...); err != nil {
+		return info, err
+	}
+
+	info.MaxGeometryCount = accelerationProperties.MaxGeometryCount
+	info.MaxInstanceCount = accelerationProperties.MaxInstanceCount
+	info.MaxPrimitiveCount = accelerationProperties.MaxPrimitiveCount
+	info.MaxRayRecursionDepth = pipelineProperties.MaxRayRecursionDepth
+	info.ShaderGroupHandleSize = pipelineProperties.ShaderGroupHandleSize
+	info.AccelerationStructureFeature = accelerationFeatures.AccelerationStructure.B()
+	info.RayTracingPipelineFeature = pipelineFeatures.RayTracingPipeline.B()
+	info.RayTracingPipelineTraceRaysIndirect = pipelineFeatures.RayTracingPipelineTraceRaysIndirect.B()
+	info.RayQueryFeature = queryFeatures.RayQuery.B()
+	return info, nil
+}
+
+// PrintRayTracingInfo prints whether the GPU at gpuIndex can trace rays,
+// how deep recursion can go and the acceleration structure limits.
+func PrintRayTracingInfo(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Ray Tracing", gpuIndex))
+	info, err := GetRayTracingInfo(v, gpuIndex)
+	if err != nil {
+		table.AddRow("Error", err)
+		fmt.Fprintln(w, "\n"+table.Render())
+		return
+	}
+	table.AddRow("Ray Tracing", info.Summary())
+	addSection(table, "Extensions")
+	table.AddRow(accelerationStructureExtensionName, checkMark(info.AccelerationStructure))
+	table.AddRow(rayTracingPipelineExtensionName, checkMark(info.RayTracingPipeline))
+	table.AddRow(rayQueryExtensionName, checkMark(info.RayQuery))
+	if info.RayTracingPipeline {
+		addSection(table, "Ray Tracing Pipeline")
+		table.AddRow("maxRayRecursionDepth", info.MaxRayRecursionDepth)
+		table.AddRow("shaderGroupHandleSize", info.ShaderGroupHandleSize)
+		table.AddRow("rayTracingPipeline", checkMark(info.RayTracingPipelineFeature))
+		table.AddRow("rayTracingPipelineTraceRaysIndirect", checkMark(info.RayTracingPipelineTraceRaysIndirect))
+	}
+	if info.RayQuery {
+		addSection(table, "Ray Query")
+		table.AddRow("rayQuery", checkMark(info.RayQueryFeature))
+	}
+	if info.AccelerationStructure {
+		addSection(table, "Acceleration Structures")
+		table.AddRow("maxGeometryCount", info.MaxGeometryCount)
+		table.AddRow("maxInstanceCount", info.MaxInstanceCount)
+		table.AddRow("maxPrimitiveCount", info.MaxPrimitiveCount)
+		table.AddRow("accelerationStructure", checkMark(info.AccelerationStructureFeature))
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
@@ -0,0 +1,136 @@
+package vulkandevice
+
+import (
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// CreateSimpleRenderPass creates a render pass with a single graphics
+// subpass writing one color attachment of colorFormat and, unless
+// depthFormat is vk.FormatUndefined, one depth attachment of depthFormat,
+// both with samples samples.
+//
+// loadOp applies to the color attachment, which is stored at the end of the
+// pass. With vk.AttachmentLoadOpLoad the color image must already be in
+// VK_IMAGE_LAYOUT_COLOR_ATTACHMENT_OPTIMAL; otherwise its contents are
+// discarded. Single-sampled color attachments end in
+// VK_IMAGE_LAYOUT_PRESENT_SRC_KHR, ready for presentation, and multisampled
+// ones in VK_IMAGE_LAYOUT_COLOR_ATTACHMENT_OPTIMAL, for resolving. The depth
+// attachment is always cleared and not stored.
+//
+// A dependency on the commands before the pass orders the layout
+// transitions of the attachments after any earlier use of the images, such
+// as the presentation engine reading the previous frame. A by-region
+// self-dependency of the subpass lets the caller record pipeline barriers
+// inside it, ordering attachment writes of earlier draws before attachment
+// reads and writes of later ones, such as for programmable blending.
+//
+// The caller owns the render pass: destroy it with DestroyRenderPass, and
+// only once no pipeline or framebuffer created with it is used anymore.
+func CreateSimpleRenderPass(v *VulkanDeviceInfo, colorFormat vk.Format, depthFormat vk.Format,
+	samples vk.SampleCountFlagBits, loadOp vk.AttachmentLoadOp) (vk.RenderPass, error) {
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return vk.NullRenderPass, ErrNoDevice
+	}
+	color := vk.AttachmentDescription{
+		Format:         colorFormat,
+		Samples:        samples,
+		LoadOp:         loadOp,
+		StoreOp:        vk.AttachmentStoreOpStore,
+		StencilLoadOp:  vk.AttachmentLoadOpDontCare,
+		StencilStoreOp: vk.AttachmentStoreOpDontCare,
+		InitialLayout:  vk.ImageLayoutUndefined,
+		FinalLayout:    vk.ImageLayoutPresentSrc,
+	}
+	if loadOp == vk.AttachmentLoadOpLoad {
+		color.InitialLayout = vk.ImageLayoutColorAttachmentOptimal
+	}
+	if samples != vk.SampleCount1Bit {
+		color.FinalLayout = vk.ImageLayoutColorAttachmentOptimal
+	}
+	attachments := []vk.AttachmentDescription{color}
+	subpass := vk.SubpassDescription{
+		PipelineBindPoint:    vk.PipelineBindPointGraphics,
+		ColorAttachmentCount: 1,
+		PColorAttachments: []vk.AttachmentReference{{
+			Attachment: 0,
+			Layout:     vk.ImageLayoutColorAttachmentOptimal,
+		}},
+	}
+	dependency := vk.SubpassDependency{
+		SrcSubpass:    vk.SubpassExternal,
+		DstSubpass:    0,
+		SrcStageMask:  vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit),
+		DstStageMask:  vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit),
+		DstAccessMask: vk.AccessFlags(vk.AccessColorAttachmentWriteBit),
+	}
+	if loadOp == vk.AttachmentLoadOpLoad {
+		dependency.DstAccessMask |= vk.AccessFlags(vk.AccessColorAttachmentReadBit)
+	}
+	self := vk.SubpassDependency{
+		SrcSubpass:      0,
+		DstSubpass:      0,
+		SrcStageMask:    vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit),
+		DstStageMask:    vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit),
+		SrcAccessMask:   vk.AccessFlags(vk.AccessColorAttachmentWriteBit),
+		DstAccessMask:   vk.AccessFlags(vk.AccessColorAttachmentReadBit | vk.AccessColorAttachmentWriteBit),
+		DependencyFlags: vk.DependencyFlags(vk.DependencyByRegionBit),
+	}
+
+	if depthFormat != vk.FormatUndefined {
+		attachments = append(attachments, vk.AttachmentDescription{
+			Format:         depthFormat,
+			Samples:        samples,
+			LoadOp:         vk.AttachmentLoadOpClear,
+			StoreOp:        vk.AttachmentStoreOpDontCare,
+			StencilLoadOp:  vk.AttachmentLoadOpClear,
+			StencilStoreOp: vk.AttachmentStoreOpDontCare,
+			InitialLayout:  vk.ImageLayoutUndefined,
+			FinalLayout:    vk.ImageLayoutDepthStencilAttachmentOptimal,
+		})
+		subpass.PDepthStencilAttachment = &vk.AttachmentReference{
+			Attachment: 1,
+			Layout:     vk.ImageLayoutDepthStencilAttachmentOptimal,
+		}
+		// The depth image of the previous frame may still be tested against.
+		dependency.SrcStageMask |= vk.PipelineStageFlags(vk.PipelineStageLateFragmentTestsBit)
+		dependency.SrcAccessMask |= vk.AccessFlags(vk.AccessDepthStencilAttachmentWriteBit)
+		dependency.DstStageMask |= vk.PipelineStageFlags(vk.PipelineStageEarlyFragmentTestsBit)
+		dependency.DstAccessMask |= vk.AccessFlags(vk.AccessDepthStencilAttachmentWriteBit)
+		self.SrcStageMask |= vk.PipelineStageFlags(vk.PipelineStageLateFragmentTestsBit)
+		self.SrcAccessMask |= vk.AccessFlags(vk.AccessDepthStencilAttachmentWriteBit)
+		self.DstStageMask |= vk.PipelineStageFlags(vk.PipelineStageEarlyFragmentTestsBit)
+		self.DstAccessMask |= vk.AccessFlags(vk.AccessDepthStencilAttachmentReadBit | vk.AccessDepthStencilAttachmentWriteBit)
+	}
+
+	createInfo := &vk.RenderPassCreateInfo{
+		SType:           vk.StructureTypeRenderPassCreateInfo,
+		AttachmentCount: uint32(len(attachments)),
+		PAttachments:    attachments,
+		SubpassCount:    1,
+		PSubpasses:      []vk.SubpassDescription{subpass},
+		DependencyCount: 2,
+		PDependencies:   []vk.SubpassDependency{dependency, self},
+	}
+	var renderPass vk.RenderPass
+	if err := vk.Error(vk.CreateRenderPass(v.device, createInfo, v.allocator, &renderPass)); err != nil {
+		err = fmt.Errorf("vkCreateRenderPass failed with %s", err)
+		return vk.NullRenderPass, err
+	}
+	return renderPass, nil
+}
+
+// DestroyRenderPass destroys rp. Pipelines and framebuffers created with it
+// must be destroyed first, or at least no longer used.
+func DestroyRenderPass(v *VulkanDeviceInfo, rp vk.RenderPass) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil || rp == vk.NullRenderPass {
+		return
+	}
+	vk.DestroyRenderPass(v.device, rp, v.allocator)
+}
@@ -0,0 +1,245 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// ErrInvalidRequirement is returned by CheckRequirements for requirements
+// that can't be parsed, such as a malformed version or an unknown limit.
+var ErrInvalidRequirement = errors.New("invalid requirement")
+
+// Requirements lists what a GPU must support, for CheckRequirements.
+type Requirements struct {
+	// Extensions are device extension names.
+	Extensions []string
+	// APIVersions are minimum Vulkan versions, such as "1.2" or "1.3.250".
+	APIVersions []string
+	// Limits are comparisons such as "maxImageDimension2D>=16384" or
+	// "maxComputeWorkGroupSize[0]>=1024", using >=, <= or ==. Limits are
+	// named as in the Vulkan spec or as in the JSON report, and boolean
+	// limits compare as 0 or 1.
+	Limits []string
+	// Features are feature names such as "samplerAnisotropy", either
+	// Vulkan 1.0 ones or, for GPUs that report them, Vulkan 1.1 to 1.3 ones.
+	Features []string
+}
+
+// RequirementResult is the outcome of one requirement.
+type RequirementResult struct {
+	Requirement string
+	Passed      bool
+	// Detail is what the GPU has, such as "16384" or "API 1.1.0".
+	Detail string
+}
+
+// String renders r as "PASS requirement (detail)" or
+// "FAIL requirement (detail)".
+func (r RequirementResult) String() string {
+	status := "FAIL"
+	if r.Passed {
+		status = "PASS"
+	}
+	if r.Detail == "" {
+		return fmt.Sprintf("%s %s", status, r.Requirement)
+	}
+	return fmt.Sprintf("%s %s (%s)", status, r.Requirement, r.Detail)
+}
+
+// CheckRequirements evaluates every requirement of req against the GPU at
+// gpuIndex, in the order extensions, API versions, limits and features. It
+// returns an error wrapping ErrInvalidRequirement, and no results, if a
+// requirement can't be parsed.
+func CheckRequirements(v *VulkanDeviceInfo, gpuIndex int, req Requirements) ([]RequirementResult, error) {
+	if gpuIndex < 0 || gpuIndex >= len(v.gpuDevices) {
+		err := fmt.Errorf("CheckRequirements: GPU index %d out of range", gpuIndex)
+		return nil, err
+	}
+	report := newDeviceReport(v, gpuIndex)
+	var results []RequirementResult
+
+	for _, name := range req.Extensions {
+		result := RequirementResult{Requirement: name, Detail: "not supported"}
+		for _, extension := range report.Extensions {
+			if extension.Name == name {
+				result.Passed = true
+				result.Detail = fmt.Sprintf("spec version %d", extension.SpecVersion)
+				break
+			}
+		}
+		results = append(results, result)
+	}
+
+	for _, version := range req.APIVersions {
+		need, err := parseAPIVersion(version)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, RequirementResult{
+			Requirement: "API " + version,
+			Passed:      report.APIVersion.Raw >= need,
+			Detail:      "API " + report.APIVersion.Version,
+		})
+	}
+
+	for _, expr := range req.Limits {
+		result, err := checkLimit(&report.Limits, expr)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	features := map[string]bool{}
+	if core, err := GetCoreFeatures(v, gpuIndex); err == nil {
+		for _, m := range []map[string]bool{core.Vulkan11, core.Vulkan12, core.Vulkan13} {
+			for name, enabled := range m {
+				features[name] = enabled
+			}
+		}
+	}
+	for name, enabled := range report.Features {
+		features[name] = enabled
+	}
+	for _, name := range req.Features {
+		result := RequirementResult{Requirement: name}
+		enabled, ok := features[name]
+		switch {
+		case !ok:
+			result.Detail = "not reported by the GPU"
+		case enabled:
+			result.Passed = true
+		default:
+			result.Detail = "not supported"
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// parseAPIVersion parses "major.minor" or "major.minor.patch".
+func parseAPIVersion(s string) (uint32, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		err := fmt.Errorf("%w: API version %q, want major.minor[.patch]", ErrInvalidRequirement, s)
+		return 0, err
+	}
+	var numbers [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			err := fmt.Errorf("%w: API version %q, want major.minor[.patch]", ErrInvalidRequirement, s)
+			return 0, err
+		}
+		numbers[i] = n
+	}
+	return vk.MakeVersion(numbers[0], numbers[1], numbers[2]), nil
+}
+
+// limitOperators are the comparisons of limit requirements, two-character
+// ones first.
+var limitOperators = []string{">=", "<=", "=="}
+
+// checkLimit evaluates a limit requirement such as
+// "maxComputeWorkGroupSize[0]>=1024" against limits.
+func checkLimit(limits *Limits, expr string) (RequirementResult, error) {
+	result := RequirementResult{Requirement: expr}
+	var name, op, want string
+	for _, candidate := range limitOperators {
+		if i := strings.Index(expr, candidate); i > 0 {
+			name, op, want = strings.TrimSpace(expr[:i]), candidate, strings.TrimSpace(expr[i+len(candidate):])
+			break
+		}
+	}
+	if op == "" {
+		err := fmt.Errorf("%w: limit %q, want NAME>=VALUE, NAME<=VALUE or NAME==VALUE", ErrInvalidRequirement, expr)
+		return result, err
+	}
+
+	index := -1
+	if i := strings.Index(name, "["); i > 0 && strings.HasSuffix(name, "]") {
+		n, err := strconv.Atoi(name[i+1 : len(name)-1])
+		if err != nil || n < 0 {
+			err := fmt.Errorf("%w: limit %q has an invalid index", ErrInvalidRequirement, expr)
+			return result, err
+		}
+		name, index = name[:i], n
+	}
+	value, ok := limitField(limits, name)
+	if !ok {
+		err := fmt.Errorf("%w: unknown limit %s", ErrInvalidRequirement, name)
+		return result, err
+	}
+	switch {
+	case value.Kind() == reflect.Array && index < 0:
+		err := fmt.Errorf("%w: limit %s is an array of %d, index it like %s[0]", ErrInvalidRequirement, name, value.Len(), name)
+		return result, err
+	case value.Kind() == reflect.Array && index >= value.Len():
+		err := fmt.Errorf("%w: limit %s only has %d elements", ErrInvalidRequirement, name, value.Len())
+		return result, err
+	case value.Kind() == reflect.Array:
+		value = value.Index(index)
+	case index >= 0:
+		err := fmt.Errorf("%w: limit %s can't be indexed", ErrInvalidRequirement, name)
+		return result, err
+	}
+
+	wantValue, err := strconv.ParseFloat(want, 64)
+	if err != nil {
+		if n, uintErr := strconv.ParseUint(want, 0, 64); uintErr == nil {
+			wantValue, err = float64(n), nil
+		}
+	}
+	if err != nil {
+		err := fmt.Errorf("%w: limit %q compares with a non-numeric value", ErrInvalidRequirement, expr)
+		return result, err
+	}
+
+	var have float64
+	switch value.Kind() {
+	case reflect.Uint32, reflect.Uint64:
+		have = float64(value.Uint())
+		result.Detail = strconv.FormatUint(value.Uint(), 10)
+	case reflect.Int32:
+		have = float64(value.Int())
+		result.Detail = strconv.FormatInt(value.Int(), 10)
+	case reflect.Float32:
+		have = value.Float()
+		result.Detail = strconv.FormatFloat(have, 'g', -1, 32)
+	case reflect.Bool:
+		if value.Bool() {
+			have = 1
+		}
+		result.Detail = strconv.FormatBool(value.Bool())
+	}
+	switch op {
+	case ">=":
+		result.Passed = have >= wantValue
+	case "<=":
+		result.Passed = have <= wantValue
+	case "==":
+		result.Passed = have == wantValue
+	}
+	return result, nil
+}
+
+// limitField returns the field of limits named name, either the Vulkan
+// name, such as maxImageDimension2D, or the JSON one, such as
+// max_image_dimension_2d.
+func limitField(limits *Limits, name string) (reflect.Value, bool) {
+	value := reflect.ValueOf(limits).Elem()
+	t := value.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		jsonName := strings.Split(field.Tag.Get("json"), ",")[0]
+		if strings.EqualFold(field.Name, name) || jsonName == name {
+			return value.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
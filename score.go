@@ -0,0 +1,182 @@
+package vulkandevice
+
+import (
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// DeviceScoreWeights controls how ScoreDevice ranks GPUs. The zero value
+// scores by device type alone.
+type DeviceScoreWeights struct {
+	// DeviceType overrides the points given per device type. If nil,
+	// discrete GPUs get 1000, integrated 100, virtual 50 and others 0.
+	DeviceType map[vk.PhysicalDeviceType]int64
+	// PerGiBVRAM is given per GiB of device-local heap memory.
+	PerGiBVRAM int64
+	// PerMiBVRAM is given per MiB of device-local heap memory, to break
+	// ties between GPUs with the same GiB count.
+	PerMiBVRAM int64
+	// PerKMaxImageDimension2D is given per 1024 texels of
+	// maxImageDimension2D.
+	PerKMaxImageDimension2D int64
+	// PerMaxImageDimension2D is given per texel of maxImageDimension2D.
+	PerMaxImageDimension2D int64
+	// PerMinorAPIVersion is given per minor API version, counting 1.0 as
+	// 10, 1.1 as 11 and so on.
+	PerMinorAPIVersion int64
+	// RequiredExtensions are device extensions the GPU must support;
+	// ScoreDevice returns -1 for GPUs missing any of them.
+	RequiredExtensions []string
+}
+
+// DefaultDeviceScoreWeights favors discrete GPUs, then VRAM, image size and
+// API version, with no required extensions.
+func DefaultDeviceScoreWeights() DeviceScoreWeights {
+	return DeviceScoreWeights{
+		PerGiBVRAM:              10,
+		PerKMaxImageDimension2D: 5,
+		PerMinorAPIVersion:      1,
+	}
+}
+
+var defaultDeviceTypeScores = map[vk.PhysicalDeviceType]int64{
+	vk.PhysicalDeviceTypeDiscreteGpu:   1000,
+	vk.PhysicalDeviceTypeIntegratedGpu: 100,
+	vk.PhysicalDeviceTypeVirtualGpu:    50,
+}
+
+// ScoreDevice rates gpu with weights. It returns -1 if gpu lacks one of
+// weights.RequiredExtensions or its extensions can't be enumerated.
+func ScoreDevice(gpu vk.PhysicalDevice, weights DeviceScoreWeights) int64 {
+	if len(weights.RequiredExtensions) > 0 {
+		extensions, err := EnumerateDeviceExtensions(gpu)
+		if err != nil {
+			return -1
+		}
+		for _, name := range weights.RequiredExtensions {
+			if !hasExtension(extensions, name) {
+				return -1
+			}
+		}
+	}
+
+	gpuProperties := getDeviceProperties(gpu)
+	typeScores := weights.DeviceType
+	if typeScores == nil {
+		typeScores = defaultDeviceTypeScores
+	}
+	score := typeScores[gpuProperties.DeviceType]
+	localMemory := deviceLocalMemory(gpu)
+	score += int64(localMemory>>30) * weights.PerGiBVRAM
+	score += int64(localMemory>>20) * weights.PerMiBVRAM
+	imageDimension := int64(gpuProperties.Limits.MaxImageDimension2D)
+	score += imageDimension / 1024 * weights.PerKMaxImageDimension2D
+	score += imageDimension * weights.PerMaxImageDimension2D
+	version := vk.Version(gpuProperties.ApiVersion)
+	score += int64(version.Major()*10+version.Minor()) * weights.PerMinorAPIVersion
+	return score
+}
+
+// SelectHighestScoringDevice returns the GPU with the highest ScoreDevice
+// score, the first one on ties. It returns nil if every GPU scores -1, so
+// it can be used in a DeviceSelector.
+func SelectHighestScoringDevice(gpus []vk.PhysicalDevice, weights DeviceScoreWeights) vk.PhysicalDevice {
+	var best vk.PhysicalDevice
+	bestScore := int64(-1)
+	for _, gpu := range gpus {
+		if score := ScoreDevice(gpu, weights); score > bestScore {
+			best, bestScore = gpu, score
+		}
+	}
+	return best
+}
+
+// deviceLocalMemory returns the total size of gpu's device-local heaps.
+func deviceLocalMemory(gpu vk.PhysicalDevice) uint64 {
+	memoryProperties := GetMemoryProperties(gpu)
+	var total uint64
+	for i := uint32(0); i < memoryProperties.MemoryHeapCount; i++ {
+		heap := memoryProperties.MemoryHeaps[i]
+		if heap.Flags&vk.MemoryHeapFlags(vk.MemoryHeapDeviceLocalBit) != 0 {
+			total += uint64(heap.Size)
+		}
+	}
+	return total
+}
+
+// DeviceScorer rates a GPU for PickDevice. A negative score disqualifies
+// it.
+type DeviceScorer func(gpu vk.PhysicalDevice) int64
+
+// WeightedDeviceScorer returns a DeviceScorer using ScoreDevice with
+// weights.
+func WeightedDeviceScorer(weights DeviceScoreWeights) DeviceScorer {
+	return func(gpu vk.PhysicalDevice) int64 {
+		return ScoreDevice(gpu, weights)
+	}
+}
+
+// deviceTypeTiers rank the device types for DefaultDeviceScorer and
+// PreferDiscreteGPU.
+var deviceTypeTiers = map[vk.PhysicalDeviceType]int64{
+	vk.PhysicalDeviceTypeDiscreteGpu:   4,
+	vk.PhysicalDeviceTypeIntegratedGpu: 3,
+	vk.PhysicalDeviceTypeVirtualGpu:    2,
+	vk.PhysicalDeviceTypeCpu:           1,
+}
+
+// DefaultDeviceScorer returns the DeviceScorer of PickDevice. It ranks GPUs
+// by type, discrete before integrated, virtual and CPU devices, with the
+// types in prefer, in order, before all of them. GPUs of the same type are
+// ranked by maxImageDimension2D, then by device-local memory. GPUs missing
+// any of requiredExtensions are disqualified. It is WeightedDeviceScorer
+// with the weights described above.
+func DefaultDeviceScorer(requiredExtensions []string, prefer ...vk.PhysicalDeviceType) DeviceScorer {
+	// The type is weighted above bit 56, so it always wins, then
+	// maxImageDimension2D above bit 32 and device-local MiB below it.
+	// Real GPUs stay far below 2^24 texels and 2^32 MiB.
+	typeScores := make(map[vk.PhysicalDeviceType]int64, len(deviceTypeTiers)+len(prefer))
+	for t, tier := range deviceTypeTiers {
+		typeScores[t] = tier << 56
+	}
+	for i, t := range prefer {
+		typeScores[t] = int64(len(deviceTypeTiers)+len(prefer)-i) << 56
+	}
+	return WeightedDeviceScorer(DeviceScoreWeights{
+		DeviceType:             typeScores,
+		PerMiBVRAM:             1,
+		PerMaxImageDimension2D: 1 << 32,
+		RequiredExtensions:     requiredExtensions,
+	})
+}
+
+// PickDevice returns the index of the GPU with the highest score, the
+// first one on ties. It fails with ErrDeviceNotFound if every GPU is
+// disqualified.
+func PickDevice(gpus []vk.PhysicalDevice, score DeviceScorer) (int, error) {
+	best, bestScore := -1, int64(-1)
+	for i, gpu := range gpus {
+		if s := score(gpu); s > bestScore {
+			best, bestScore = i, s
+		}
+	}
+	if best < 0 {
+		err := fmt.Errorf("%w: every GPU was disqualified", ErrDeviceNotFound)
+		return 0, err
+	}
+	return best, nil
+}
+
+// WithPickDevice makes NewVulkanDevice use the GPU chosen by PickDevice
+// with score, such as DefaultDeviceScorer(nil). It is a device selector, so
+// it replaces WithDeviceSelector.
+func WithPickDevice(score DeviceScorer) Option {
+	return WithDeviceSelector(func(gpus []vk.PhysicalDevice) vk.PhysicalDevice {
+		i, err := PickDevice(gpus, score)
+		if err != nil {
+			return nil
+		}
+		return gpus[i]
+	})
+}
@@ -0,0 +1,80 @@
+package vulkandevice
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// spirvMagic is the first word of every SPIR-V module.
+const spirvMagic = 0x07230203
+
+// ErrInvalidSPIRV is returned when shader code isn't a SPIR-V module: it
+// doesn't start with the SPIR-V magic number or isn't made of 4-byte words.
+var ErrInvalidSPIRV = errors.New("invalid SPIR-V")
+
+// CreateShaderModuleFromBytes creates a shader module from the SPIR-V
+// module spirv, after checking its magic number. Destroy it with
+// DestroyShaderModule.
+func CreateShaderModuleFromBytes(v *VulkanDeviceInfo, spirv []byte) (vk.ShaderModule, error) {
+	if len(spirv) < 4 || len(spirv)%4 != 0 {
+		err := fmt.Errorf("%w: %d bytes is not a whole number of words", ErrInvalidSPIRV, len(spirv))
+		return vk.NullShaderModule, err
+	}
+	code := make([]uint32, len(spirv)/4)
+	for i := range code {
+		code[i] = binary.LittleEndian.Uint32(spirv[i*4:])
+	}
+	if code[0] != spirvMagic {
+		err := fmt.Errorf("%w: magic number %#08x, want %#08x", ErrInvalidSPIRV, code[0], spirvMagic)
+		return vk.NullShaderModule, err
+	}
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return vk.NullShaderModule, ErrNoDevice
+	}
+	createInfo := &vk.ShaderModuleCreateInfo{
+		SType:    vk.StructureTypeShaderModuleCreateInfo,
+		CodeSize: uint(len(spirv)),
+		PCode:    code,
+	}
+	var module vk.ShaderModule
+	if err := vk.Error(vk.CreateShaderModule(v.device, createInfo, v.allocator, &module)); err != nil {
+		err = fmt.Errorf("vkCreateShaderModule failed with %s", err)
+		return vk.NullShaderModule, err
+	}
+	return module, nil
+}
+
+// CreateShaderModuleFromFile creates a shader module from the SPIR-V file
+// at path, such as one compiled by glslc.
+func CreateShaderModuleFromFile(v *VulkanDeviceInfo, path string) (vk.ShaderModule, error) {
+	spirv, err := os.ReadFile(path)
+	if err != nil {
+		return vk.NullShaderModule, err
+	}
+	if len(spirv)%4 != 0 {
+		err := fmt.Errorf("%w: %s is %d bytes, not a multiple of 4", ErrInvalidSPIRV, path, len(spirv))
+		return vk.NullShaderModule, err
+	}
+	module, err := CreateShaderModuleFromBytes(v, spirv)
+	if err != nil {
+		err = fmt.Errorf("%s: %w", path, err)
+		return vk.NullShaderModule, err
+	}
+	return module, nil
+}
+
+// DestroyShaderModule destroys mod. Pipelines created from it stay valid.
+func DestroyShaderModule(v *VulkanDeviceInfo, mod vk.ShaderModule) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil || mod == vk.NullShaderModule {
+		return
+	}
+	vk.DestroyShaderModule(v.device, mod, v.allocator)
+}
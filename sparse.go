@@ -0,0 +1,42 @@
+package vulkandevice
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/xlab/tablewriter"
+)
+
+// PrintSparseResources prints the sparse binding and residency features,
+// the sparse properties and the sparse address space of the GPU at
+// gpuIndex.
+func PrintSparseResources(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	gpu := v.gpuDevices[gpuIndex]
+	gpuProperties := getDeviceProperties(gpu)
+	sparse := gpuProperties.SparseProperties
+	features := GetDeviceFeatures(gpu)
+
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Sparse Resources", gpuIndex))
+	addSection(table, "Features")
+	table.AddRow("sparseBinding", checkMark(features.SparseBinding.B()))
+	table.AddRow("sparseResidencyBuffer", checkMark(features.SparseResidencyBuffer.B()))
+	table.AddRow("sparseResidencyImage2D", checkMark(features.SparseResidencyImage2D.B()))
+	table.AddRow("sparseResidencyImage3D", checkMark(features.SparseResidencyImage3D.B()))
+	table.AddRow("sparseResidency2Samples", checkMark(features.SparseResidency2Samples.B()))
+	table.AddRow("sparseResidency4Samples", checkMark(features.SparseResidency4Samples.B()))
+	table.AddRow("sparseResidency8Samples", checkMark(features.SparseResidency8Samples.B()))
+	table.AddRow("sparseResidency16Samples", checkMark(features.SparseResidency16Samples.B()))
+	table.AddRow("sparseResidencyAliased", checkMark(features.SparseResidencyAliased.B()))
+	addSection(table, "Properties")
+	table.AddRow("residencyStandard2DBlockShape", checkMark(sparse.ResidencyStandard2DBlockShape.B()))
+	table.AddRow("residencyStandard2DMultisampleBlockShape", checkMark(sparse.ResidencyStandard2DMultisampleBlockShape.B()))
+	table.AddRow("residencyStandard3DBlockShape", checkMark(sparse.ResidencyStandard3DBlockShape.B()))
+	table.AddRow("residencyAlignedMipSize", checkMark(sparse.ResidencyAlignedMipSize.B()))
+	table.AddRow("residencyNonResidentStrict", checkMark(sparse.ResidencyNonResidentStrict.B()))
+	addSection(table, "Limits")
+	table.AddRow("sparseAddressSpaceSize", formatGiB(uint64(gpuProperties.Limits.SparseAddressSpaceSize)))
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
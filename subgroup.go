@@ -0,0 +1,122 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// physicalDeviceSubgroupProperties has the C layout of
+// VkPhysicalDeviceSubgroupProperties.
+type physicalDeviceSubgroupProperties struct {
+	chainHeader
+	SubgroupSize              uint32
+	SupportedStages           vk.ShaderStageFlags
+	SupportedOperations       vk.SubgroupFeatureFlags
+	QuadOperationsInAllStages vk.Bool32
+}
+
+// SubgroupInfo holds the subgroup (warp or wavefront) properties of a GPU,
+// with the flags decoded into names joined by "|".
+type SubgroupInfo struct {
+	SubgroupSize              uint32 `json:"subgroup_size"`
+	SupportedStages           string `json:"supported_stages"`
+	SupportedOperations       string `json:"supported_operations"`
+	QuadOperationsInAllStages bool   `json:"quad_operations_in_all_stages"`
+	// Properties are the undecoded properties.
+	Properties vk.PhysicalDeviceSubgroupProperties `json:"-"`
+}
+
+// ErrRequiresVulkan11 is returned by queries of structures added in Vulkan
+// 1.1, such as GetSubgroupProperties, on GPUs only supporting Vulkan 1.0.
+var ErrRequiresVulkan11 = errors.New("requires Vulkan 1.1")
+
+// GetSubgroupProperties queries the subgroup size and the stages and
+// operations supporting subgroups of the GPU at gpuIndex, which must
+// support Vulkan 1.1. Like GetPhysicalDeviceProperties2, it takes a GPU
+// index because the query goes through v's instance.
+func GetSubgroupProperties(v *VulkanDeviceInfo, gpuIndex int) (SubgroupInfo, error) {
+	var info SubgroupInfo
+	if apiVersion := getDeviceProperties(v.gpuDevices[gpuIndex]).ApiVersion; apiVersion < vk.MakeVersion(1, 1, 0) {
+		err := fmt.Errorf("%w: GPU %d only supports Vulkan %s", ErrRequiresVulkan11, gpuIndex, vk.Version(apiVersion))
+		return info, err
+	}
+	subgroup := physicalDeviceSubgroupProperties{chainHeader: chainHeader{SType: vk.StructureTypePhysicalDeviceSubgroupProperties}}
+	_, err := GetPhysicalDeviceProperties2(v, gpuIndex, ChainedStruct{unsafe.Pointer(&subgroup), unsafe.Sizeof(subgroup)})
+	if err != nil {
+		return info, err
+	}
+	info.SubgroupSize = subgroup.SubgroupSize
+	info.SupportedStages = shaderStageFlags(subgroup.SupportedStages)
+	info.SupportedOperations = subgroupFeatureFlags(subgroup.SupportedOperations)
+	info.QuadOperationsInAllStages = subgroup.QuadOperationsInAllStages.B()
+	info.Properties = vk.PhysicalDeviceSubgroupProperties{
+		SType:                     vk.StructureTypePhysicalDeviceSubgroupProperties,
+		SubgroupSize:              subgroup.SubgroupSize,
+		SupportedStages:           subgroup.SupportedStages,
+		SupportedOperations:       subgroup.SupportedOperations,
+		QuadOperationsInAllStages: subgroup.QuadOperationsInAllStages,
+	}
+	return info, nil
+}
+
+// PrintSubgroupInfo prints the subgroup properties of the GPU at gpuIndex.
+func PrintSubgroupInfo(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Subgroup Properties", gpuIndex))
+	info, err := GetSubgroupProperties(v, gpuIndex)
+	if errors.Is(err, ErrRequiresVulkan11) {
+		table.AddRow("Subgroups", "requires Vulkan 1.1")
+	} else if err != nil {
+		table.AddRow("Error", err)
+	} else {
+		table.AddRow("Subgroup Size", info.SubgroupSize)
+		table.AddRow("Supported Stages", info.SupportedStages)
+		table.AddRow("Supported Operations", info.SupportedOperations)
+		table.AddRow("Quad Operations In All Stages", checkMark(info.QuadOperationsInAllStages))
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
+
+var shaderStageFlagTable = []flagName{
+	{uint32(vk.ShaderStageVertexBit), "VERTEX"},
+	{uint32(vk.ShaderStageTessellationControlBit), "TESSELLATION_CONTROL"},
+	{uint32(vk.ShaderStageTessellationEvaluationBit), "TESSELLATION_EVALUATION"},
+	{uint32(vk.ShaderStageGeometryBit), "GEOMETRY"},
+	{uint32(vk.ShaderStageFragmentBit), "FRAGMENT"},
+	{uint32(vk.ShaderStageComputeBit), "COMPUTE"},
+	{uint32(vk.ShaderStageRaygenBitNvx), "RAYGEN"},
+	{uint32(vk.ShaderStageAnyHitBitNvx), "ANY_HIT"},
+	{uint32(vk.ShaderStageClosestHitBitNvx), "CLOSEST_HIT"},
+	{uint32(vk.ShaderStageMissBitNvx), "MISS"},
+	{uint32(vk.ShaderStageIntersectionBitNvx), "INTERSECTION"},
+	{uint32(vk.ShaderStageCallableBitNvx), "CALLABLE"},
+	{uint32(vk.ShaderStageTaskBitNv), "TASK"},
+	{uint32(vk.ShaderStageMeshBitNv), "MESH"},
+}
+
+var subgroupFeatureFlagTable = []flagName{
+	{uint32(vk.SubgroupFeatureBasicBit), "BASIC"},
+	{uint32(vk.SubgroupFeatureVoteBit), "VOTE"},
+	{uint32(vk.SubgroupFeatureArithmeticBit), "ARITHMETIC"},
+	{uint32(vk.SubgroupFeatureBallotBit), "BALLOT"},
+	{uint32(vk.SubgroupFeatureShuffleBit), "SHUFFLE"},
+	{uint32(vk.SubgroupFeatureShuffleRelativeBit), "SHUFFLE_RELATIVE"},
+	{uint32(vk.SubgroupFeatureClusteredBit), "CLUSTERED"},
+	{uint32(vk.SubgroupFeatureQuadBit), "QUAD"},
+	{uint32(vk.SubgroupFeaturePartitionedBitNv), "PARTITIONED_NV"},
+}
+
+func shaderStageFlags(flags vk.ShaderStageFlags) string {
+	return joinFlags(flagNames(uint32(flags), shaderStageFlagTable))
+}
+
+func subgroupFeatureFlags(flags vk.SubgroupFeatureFlags) string {
+	return joinFlags(flagNames(uint32(flags), subgroupFeatureFlagTable))
+}
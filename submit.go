@@ -0,0 +1,50 @@
+package vulkandevice
+
+import (
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// SubmitInfo describes one batch of QueueSubmit. WaitDstStageMask holds the
+// stages waiting on each semaphore of WaitSemaphores, so both must have the
+// same length.
+type SubmitInfo struct {
+	WaitSemaphores   []vk.Semaphore
+	WaitDstStageMask []vk.PipelineStageFlags
+	CommandBuffers   []vk.CommandBuffer
+	SignalSemaphores []vk.Semaphore
+}
+
+// QueueSubmit submits the batches of submits to queue in order, signaling
+// fence, unless it is vk.NullFence, once they have all completed. The
+// vkQueueSubmit error is wrapped in the returned error.
+func QueueSubmit(v *VulkanDeviceInfo, queue vk.Queue, submits []SubmitInfo, fence vk.Fence) error {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.device == nil {
+		return ErrNoDevice
+	}
+	submitInfos := make([]vk.SubmitInfo, len(submits))
+	for i, s := range submits {
+		if len(s.WaitSemaphores) != len(s.WaitDstStageMask) {
+			err := fmt.Errorf("submit %d: %d wait semaphores but %d wait stage masks", i, len(s.WaitSemaphores), len(s.WaitDstStageMask))
+			return err
+		}
+		submitInfos[i] = vk.SubmitInfo{
+			SType:                vk.StructureTypeSubmitInfo,
+			WaitSemaphoreCount:   uint32(len(s.WaitSemaphores)),
+			PWaitSemaphores:      s.WaitSemaphores,
+			PWaitDstStageMask:    s.WaitDstStageMask,
+			CommandBufferCount:   uint32(len(s.CommandBuffers)),
+			PCommandBuffers:      s.CommandBuffers,
+			SignalSemaphoreCount: uint32(len(s.SignalSemaphores)),
+			PSignalSemaphores:    s.SignalSemaphores,
+		}
+	}
+	if err := vk.Error(vk.QueueSubmit(queue, uint32(len(submitInfos)), submitInfos, fence)); err != nil {
+		err = fmt.Errorf("vkQueueSubmit failed with %s", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	vk "github.com/vulkan-go/vulkan"
+	"github.com/xlab/tablewriter"
+)
+
+// ErrSurfaceCreationFailed is returned, wrapping the cause, when a platform
+// surface can't be created.
+var ErrSurfaceCreationFailed = errors.New("surface creation failed")
+
+// ErrNoSurfaceFormats is returned by GetSurfaceFormats when the selected GPU
+// reports no formats for the surface.
+var ErrNoSurfaceFormats = errors.New("no surface formats available")
+
+// instanceExtensionEnabled reports whether v's instance was created with
+// the extension name.
+func (v *VulkanDeviceInfo) instanceExtensionEnabled(name string) bool {
+	for _, enabled := range v.enabledInstanceExtensions {
+		if enabled == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetSurfaceFormats returns the formats and color spaces the selected GPU
+// supports for swapchains on surface.
+func GetSurfaceFormats(v *VulkanDeviceInfo, surface vk.Surface) ([]vk.SurfaceFormat, error) {
+	formats, err := getSurfaceFormats(v.gpuDevices[v.gpuIndex], surface)
+	if err != nil {
+		return nil, err
+	}
+	if len(formats) == 0 {
+		return nil, ErrNoSurfaceFormats
+	}
+	return formats, nil
+}
+
+// GetPresentModes returns the present modes the selected GPU supports for
+// surface.
+func GetPresentModes(v *VulkanDeviceInfo, surface vk.Surface) ([]vk.PresentMode, error) {
+	return getPresentModes(v.gpuDevices[v.gpuIndex], surface)
+}
+
+// GetSurfaceCapabilities returns the capabilities of surface on the
+// selected GPU.
+func GetSurfaceCapabilities(v *VulkanDeviceInfo, surface vk.Surface) (vk.SurfaceCapabilities, error) {
+	var capabilities vk.SurfaceCapabilities
+	err := vk.Error(vk.GetPhysicalDeviceSurfaceCapabilities(v.gpuDevices[v.gpuIndex], surface, &capabilities))
+	if err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceSurfaceCapabilitiesKHR failed with %s", err)
+		return capabilities, err
+	}
+	capabilities.Deref()
+	capabilities.CurrentExtent.Deref()
+	capabilities.MinImageExtent.Deref()
+	capabilities.MaxImageExtent.Deref()
+	return capabilities, nil
+}
+
+// PrintSurfaceCapabilities prints the capabilities of surface on the
+// selected GPU.
+func PrintSurfaceCapabilities(w io.Writer, v *VulkanDeviceInfo, surface vk.Surface) {
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Surface Capabilities", v.gpuIndex))
+
+	capabilities, err := GetSurfaceCapabilities(v, surface)
+	if err != nil {
+		table.AddRow("Error", err.Error())
+		fmt.Fprintln(w, "\n"+table.Render())
+		return
+	}
+	table.AddRow("Min Image Count", capabilities.MinImageCount)
+	if capabilities.MaxImageCount == 0 {
+		table.AddRow("Max Image Count", "Unlimited")
+	} else {
+		table.AddRow("Max Image Count", capabilities.MaxImageCount)
+	}
+	if capabilities.CurrentExtent.Width == vk.MaxUint32 {
+		table.AddRow("Current Extent", "Set by swapchain")
+	} else {
+		table.AddRow("Current Extent", formatExtent2D(capabilities.CurrentExtent))
+	}
+	table.AddRow("Min Extent", formatExtent2D(capabilities.MinImageExtent))
+	table.AddRow("Max Extent", formatExtent2D(capabilities.MaxImageExtent))
+	table.AddRow("Max Image Array Layers", capabilities.MaxImageArrayLayers)
+	table.AddRow("Supported Transforms", joinFlags(flagNames(uint32(capabilities.SupportedTransforms), surfaceTransformFlagTable)))
+	table.AddRow("Current Transform", joinFlags(flagNames(uint32(capabilities.CurrentTransform), surfaceTransformFlagTable)))
+	table.AddRow("Composite Alpha", joinFlags(flagNames(uint32(capabilities.SupportedCompositeAlpha), compositeAlphaFlagTable)))
+	table.AddRow("Usage Flags", joinFlags(flagNames(uint32(capabilities.SupportedUsageFlags), imageUsageFlagTable)))
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
+
+// PrintSurfaceFormats prints the format and color space pairs the selected
+// GPU supports for swapchains on surface.
+func PrintSurfaceFormats(w io.Writer, v *VulkanDeviceInfo, surface vk.Surface) {
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle(fmt.Sprintf("GPU %d Surface Formats", v.gpuIndex))
+
+	formats, err := GetSurfaceFormats(v, surface)
+	if err != nil {
+		table.AddRow("Error", err.Error())
+		fmt.Fprintln(w, "\n"+table.Render())
+		return
+	}
+	table.AddHeaders("Format", "Color Space")
+	for _, format := range formats {
+		table.AddRow(FormatName(format.Format), ColorSpaceName(format.ColorSpace))
+	}
+
+	fmt.Fprintln(w, "\n"+table.Render())
+}
+
+// PrintPresentModes prints the present modes the selected GPU supports for
+// surface, and which of the GPU's queue families can present to it.
+func PrintPresentModes(w io.Writer, v *VulkanDeviceInfo, surface vk.Surface) {
+	modes := tablewriter.CreateTable()
+	modes.UTF8Box()
+	modes.AddTitle(fmt.Sprintf("GPU %d Present Modes", v.gpuIndex))
+	presentModes, err := GetPresentModes(v, surface)
+	if err != nil {
+		modes.AddRow("Error", err.Error())
+	} else {
+		modes.AddHeaders("Present Mode", "Notes")
+		for _, mode := range presentModes {
+			note := ""
+			if mode == vk.PresentModeFifo {
+				note = "Always supported"
+			}
+			modes.AddRow(PresentModeName(mode), note)
+		}
+	}
+
+	gpu := v.gpuDevices[v.gpuIndex]
+	families := tablewriter.CreateTable()
+	families.UTF8Box()
+	families.AddTitle(fmt.Sprintf("GPU %d Queue Family Present Support", v.gpuIndex))
+	families.AddHeaders("Family", "Flags", "Can Present")
+	for i, family := range v.queueFamilies[v.gpuIndex] {
+		var supported vk.Bool32
+		presentSupport := "No"
+		err := vk.Error(vk.GetPhysicalDeviceSurfaceSupport(gpu, uint32(i), surface, &supported))
+		if err != nil {
+			presentSupport = fmt.Sprintf("vkGetPhysicalDeviceSurfaceSupportKHR failed with %s", err)
+		} else if supported.B() {
+			presentSupport = "Yes"
+		}
+		families.AddRow(i, queueFlags(family.QueueFlags), presentSupport)
+	}
+
+	fmt.Fprintln(w, "\n"+modes.Render())
+	fmt.Fprintln(w, "\n"+families.Render())
+}
+
+func getSurfaceFormats(gpu vk.PhysicalDevice, surface vk.Surface) ([]vk.SurfaceFormat, error) {
+	var formatCount uint32
+	err := vk.Error(vk.GetPhysicalDeviceSurfaceFormats(gpu, surface, &formatCount, nil))
+	if err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceSurfaceFormatsKHR failed with %s", err)
+		return nil, err
+	}
+	formats := make([]vk.SurfaceFormat, formatCount)
+	err = vk.Error(vk.GetPhysicalDeviceSurfaceFormats(gpu, surface, &formatCount, formats))
+	if err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceSurfaceFormatsKHR failed with %s", err)
+		return nil, err
+	}
+	for i := range formats {
+		formats[i].Deref()
+	}
+	return formats, nil
+}
+
+func getPresentModes(gpu vk.PhysicalDevice, surface vk.Surface) ([]vk.PresentMode, error) {
+	var modeCount uint32
+	err := vk.Error(vk.GetPhysicalDeviceSurfacePresentModes(gpu, surface, &modeCount, nil))
+	if err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceSurfacePresentModesKHR failed with %s", err)
+		return nil, err
+	}
+	modes := make([]vk.PresentMode, modeCount)
+	err = vk.Error(vk.GetPhysicalDeviceSurfacePresentModes(gpu, surface, &modeCount, modes))
+	if err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceSurfacePresentModesKHR failed with %s", err)
+		return nil, err
+	}
+	return modes, nil
+}
+
+// PrintSurfaceInfo prints the capabilities, formats and present modes of
+// v's surface if gpuIndex is the selected GPU, which is the only one the
+// surface is queried on.
+func PrintSurfaceInfo(w io.Writer, v *VulkanDeviceInfo, gpuIndex int) {
+	if gpuIndex == v.gpuIndex && v.surface != vk.NullSurface {
+		PrintSurfaceCapabilities(w, v, v.surface)
+		PrintSurfaceFormats(w, v, v.surface)
+		PrintPresentModes(w, v, v.surface)
+	}
+}
+
+// formatExtent2D renders an extent as "1920x1080".
+func formatExtent2D(extent vk.Extent2D) string {
+	return fmt.Sprintf("%dx%d", extent.Width, extent.Height)
+}
+
+var surfaceTransformFlagTable = []flagName{
+	{uint32(vk.SurfaceTransformIdentityBit), "IDENTITY"},
+	{uint32(vk.SurfaceTransformRotate90Bit), "ROTATE_90"},
+	{uint32(vk.SurfaceTransformRotate180Bit), "ROTATE_180"},
+	{uint32(vk.SurfaceTransformRotate270Bit), "ROTATE_270"},
+	{uint32(vk.SurfaceTransformHorizontalMirrorBit), "HORIZONTAL_MIRROR"},
+	{uint32(vk.SurfaceTransformHorizontalMirrorRotate90Bit), "HORIZONTAL_MIRROR_ROTATE_90"},
+	{uint32(vk.SurfaceTransformHorizontalMirrorRotate180Bit), "HORIZONTAL_MIRROR_ROTATE_180"},
+	{uint32(vk.SurfaceTransformHorizontalMirrorRotate270Bit), "HORIZONTAL_MIRROR_ROTATE_270"},
+	{uint32(vk.SurfaceTransformInheritBit), "INHERIT"},
+}
+
+var compositeAlphaFlagTable = []flagName{
+	{uint32(vk.CompositeAlphaOpaqueBit), "OPAQUE"},
+	{uint32(vk.CompositeAlphaPreMultipliedBit), "PRE_MULTIPLIED"},
+	{uint32(vk.CompositeAlphaPostMultipliedBit), "POST_MULTIPLIED"},
+	{uint32(vk.CompositeAlphaInheritBit), "INHERIT"},
+}
+
+var imageUsageFlagTable = []flagName{
+	{uint32(vk.ImageUsageTransferSrcBit), "TRANSFER_SRC"},
+	{uint32(vk.ImageUsageTransferDstBit), "TRANSFER_DST"},
+	{uint32(vk.ImageUsageSampledBit), "SAMPLED"},
+	{uint32(vk.ImageUsageStorageBit), "STORAGE"},
+	{uint32(vk.ImageUsageColorAttachmentBit), "COLOR_ATTACHMENT"},
+	{uint32(vk.ImageUsageDepthStencilAttachmentBit), "DEPTH_STENCIL_ATTACHMENT"},
+	{uint32(vk.ImageUsageTransientAttachmentBit), "TRANSIENT_ATTACHMENT"},
+	{uint32(vk.ImageUsageInputAttachmentBit), "INPUT_ATTACHMENT"},
+}
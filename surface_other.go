@@ -0,0 +1,8 @@
+//go:build !windows && !linux
+
+package vulkandevice
+
+// platformSurfaceExtensions are enabled on the instance, when available, so
+// a surface can be created for a window. No window system is supported on
+// this platform yet.
+var platformSurfaceExtensions []string
@@ -0,0 +1,93 @@
+//go:build linux && wayland
+
+package vulkandevice
+
+/*
+#include <stdint.h>
+#include <stddef.h>
+
+// lookup_instance_proc is defined in proc.go.
+extern void *lookup_instance_proc(void *instance, const char *name);
+
+// wayland_surface_create_info has the layout of
+// VkWaylandSurfaceCreateInfoKHR.
+typedef struct {
+	int32_t sType;
+	const void *pNext;
+	uint32_t flags;
+	void *display;
+	void *surface;
+} wayland_surface_create_info;
+
+typedef int32_t (*create_wayland_surface_t)(void *instance,
+	const wayland_surface_create_info *createInfo, const void *allocator, uint64_t *surface);
+
+static int32_t create_wayland_surface(void *instance, uintptr_t display, uintptr_t wlSurface,
+	const void *allocator, uint64_t *surface, int *found) {
+	create_wayland_surface_t fn = (create_wayland_surface_t)lookup_instance_proc(instance, "vkCreateWaylandSurfaceKHR");
+	*found = fn != NULL;
+	if (fn == NULL) {
+		return 0;
+	}
+	wayland_surface_create_info createInfo = {
+		1000006000, // VK_STRUCTURE_TYPE_WAYLAND_SURFACE_CREATE_INFO_KHR
+		NULL,
+		0,
+		(void *)display,
+		(void *)wlSurface,
+	};
+	return fn(instance, &createInfo, allocator, surface);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// waylandSurfaceExtensionName is the instance extension providing
+// vkCreateWaylandSurfaceKHR.
+const waylandSurfaceExtensionName = "VK_KHR_wayland_surface"
+
+// platformSurfaceExtensions are enabled on the instance, when available, so
+// a surface can be created for a window.
+var platformSurfaceExtensions = []string{vk.KhrSurfaceExtensionName, waylandSurfaceExtensionName}
+
+// CreateWaylandSurface creates a surface for wlSurface, a wl_surface pointer,
+// on display, a wl_display pointer, and sets it as v's surface, which
+// Destroy releases.
+func CreateWaylandSurface(v *VulkanDeviceInfo, display, wlSurface uintptr) (vk.Surface, error) {
+	v.mu.RLock()
+	instance, allocator := v.instance, v.allocator
+	enabled := v.instanceExtensionEnabled(waylandSurfaceExtensionName)
+	hasXCB := hasExtension(v.instanceExtensions, "VK_KHR_xcb_surface")
+	logger := v.logger
+	v.mu.RUnlock()
+	if !enabled && hasXCB {
+		logger.Warn("the driver supports VK_KHR_xcb_surface but not " + waylandSurfaceExtensionName +
+			", rebuild without -tags wayland to use X11")
+	}
+	if !enabled {
+		err := fmt.Errorf("%w: instance extension %s is not enabled, the Vulkan driver does not support it", ErrSurfaceCreationFailed, waylandSurfaceExtensionName)
+		return vk.NullSurface, err
+	}
+
+	var surface C.uint64_t
+	var found C.int
+	ret := vk.Result(C.create_wayland_surface(unsafe.Pointer(instance), C.uintptr_t(display), C.uintptr_t(wlSurface),
+		unsafe.Pointer(allocator), &surface, &found))
+	if found == 0 {
+		err := fmt.Errorf("%w: vkCreateWaylandSurfaceKHR not available", ErrSurfaceCreationFailed)
+		return vk.NullSurface, err
+	}
+	if err := vk.Error(ret); err != nil {
+		err = fmt.Errorf("%w: vkCreateWaylandSurfaceKHR failed with %s", ErrSurfaceCreationFailed, err)
+		return vk.NullSurface, err
+	}
+	s := vk.SurfaceFromPointer(uintptr(surface))
+	v.SetSurface(s)
+	return s, nil
+}
@@ -0,0 +1,85 @@
+//go:build windows
+
+package vulkandevice
+
+/*
+#include <stdint.h>
+#include <stddef.h>
+
+// lookup_instance_proc is defined in proc.go.
+extern void *lookup_instance_proc(void *instance, const char *name);
+
+// win32_surface_create_info has the layout of VkWin32SurfaceCreateInfoKHR.
+typedef struct {
+	int32_t sType;
+	const void *pNext;
+	uint32_t flags;
+	void *hinstance;
+	void *hwnd;
+} win32_surface_create_info;
+
+typedef int32_t (*create_win32_surface_t)(void *instance,
+	const win32_surface_create_info *createInfo, const void *allocator, uint64_t *surface);
+
+static int32_t create_win32_surface(void *instance, uintptr_t hinstance, uintptr_t hwnd,
+	const void *allocator, uint64_t *surface, int *found) {
+	create_win32_surface_t fn = (create_win32_surface_t)lookup_instance_proc(instance, "vkCreateWin32SurfaceKHR");
+	*found = fn != NULL;
+	if (fn == NULL) {
+		return 0;
+	}
+	win32_surface_create_info createInfo = {
+		1000009000, // VK_STRUCTURE_TYPE_WIN32_SURFACE_CREATE_INFO_KHR
+		NULL,
+		0,
+		(void *)hinstance,
+		(void *)hwnd,
+	};
+	return fn(instance, &createInfo, allocator, surface);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// win32SurfaceExtensionName is the instance extension providing
+// vkCreateWin32SurfaceKHR.
+const win32SurfaceExtensionName = "VK_KHR_win32_surface"
+
+// platformSurfaceExtensions are enabled on the instance, when available, so
+// a surface can be created for a window.
+var platformSurfaceExtensions = []string{vk.KhrSurfaceExtensionName, win32SurfaceExtensionName}
+
+// CreateWin32Surface creates a surface for the window hwnd of the module
+// hinstance and sets it as v's surface, which Destroy releases.
+func CreateWin32Surface(v *VulkanDeviceInfo, hwnd, hinstance uintptr) (vk.Surface, error) {
+	v.mu.RLock()
+	instance, allocator := v.instance, v.allocator
+	enabled := v.instanceExtensionEnabled(win32SurfaceExtensionName)
+	v.mu.RUnlock()
+	if !enabled {
+		err := fmt.Errorf("%w: instance extension %s is not enabled, the Vulkan driver does not support it", ErrSurfaceCreationFailed, win32SurfaceExtensionName)
+		return vk.NullSurface, err
+	}
+
+	var surface C.uint64_t
+	var found C.int
+	ret := vk.Result(C.create_win32_surface(unsafe.Pointer(instance), C.uintptr_t(hinstance), C.uintptr_t(hwnd),
+		unsafe.Pointer(allocator), &surface, &found))
+	if found == 0 {
+		err := fmt.Errorf("%w: vkCreateWin32SurfaceKHR not available", ErrSurfaceCreationFailed)
+		return vk.NullSurface, err
+	}
+	if err := vk.Error(ret); err != nil {
+		err = fmt.Errorf("%w: vkCreateWin32SurfaceKHR failed with %s", ErrSurfaceCreationFailed, err)
+		return vk.NullSurface, err
+	}
+	s := vk.SurfaceFromPointer(uintptr(surface))
+	v.SetSurface(s)
+	return s, nil
+}
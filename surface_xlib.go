@@ -0,0 +1,85 @@
+//go:build linux && !wayland
+
+package vulkandevice
+
+/*
+#include <stdint.h>
+#include <stddef.h>
+
+// lookup_instance_proc is defined in proc.go.
+extern void *lookup_instance_proc(void *instance, const char *name);
+
+// xlib_surface_create_info has the layout of VkXlibSurfaceCreateInfoKHR.
+typedef struct {
+	int32_t sType;
+	const void *pNext;
+	uint32_t flags;
+	void *dpy;
+	unsigned long window;
+} xlib_surface_create_info;
+
+typedef int32_t (*create_xlib_surface_t)(void *instance,
+	const xlib_surface_create_info *createInfo, const void *allocator, uint64_t *surface);
+
+static int32_t create_xlib_surface(void *instance, uintptr_t display, uintptr_t window,
+	const void *allocator, uint64_t *surface, int *found) {
+	create_xlib_surface_t fn = (create_xlib_surface_t)lookup_instance_proc(instance, "vkCreateXlibSurfaceKHR");
+	*found = fn != NULL;
+	if (fn == NULL) {
+		return 0;
+	}
+	xlib_surface_create_info createInfo = {
+		1000004000, // VK_STRUCTURE_TYPE_XLIB_SURFACE_CREATE_INFO_KHR
+		NULL,
+		0,
+		(void *)display,
+		(unsigned long)window,
+	};
+	return fn(instance, &createInfo, allocator, surface);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// xlibSurfaceExtensionName is the instance extension providing
+// vkCreateXlibSurfaceKHR.
+const xlibSurfaceExtensionName = "VK_KHR_xlib_surface"
+
+// platformSurfaceExtensions are enabled on the instance, when available, so
+// a surface can be created for a window.
+var platformSurfaceExtensions = []string{vk.KhrSurfaceExtensionName, xlibSurfaceExtensionName}
+
+// CreateX11Surface creates a surface for the X11 window on display, an Xlib
+// Display pointer, and sets it as v's surface, which Destroy releases.
+func CreateX11Surface(v *VulkanDeviceInfo, display, window uintptr) (vk.Surface, error) {
+	v.mu.RLock()
+	instance, allocator := v.instance, v.allocator
+	enabled := v.instanceExtensionEnabled(xlibSurfaceExtensionName)
+	v.mu.RUnlock()
+	if !enabled {
+		err := fmt.Errorf("%w: instance extension %s is not enabled, the Vulkan driver does not support it", ErrSurfaceCreationFailed, xlibSurfaceExtensionName)
+		return vk.NullSurface, err
+	}
+
+	var surface C.uint64_t
+	var found C.int
+	ret := vk.Result(C.create_xlib_surface(unsafe.Pointer(instance), C.uintptr_t(display), C.uintptr_t(window),
+		unsafe.Pointer(allocator), &surface, &found))
+	if found == 0 {
+		err := fmt.Errorf("%w: vkCreateXlibSurfaceKHR not available", ErrSurfaceCreationFailed)
+		return vk.NullSurface, err
+	}
+	if err := vk.Error(ret); err != nil {
+		err = fmt.Errorf("%w: vkCreateXlibSurfaceKHR failed with %s", ErrSurfaceCreationFailed, err)
+		return vk.NullSurface, err
+	}
+	s := vk.SurfaceFromPointer(uintptr(surface))
+	v.SetSurface(s)
+	return s, nil
+}
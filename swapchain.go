@@ -0,0 +1,260 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// ErrNoSurface is returned by CreateSwapchain when no surface has been set
+// on the VulkanDeviceInfo.
+var ErrNoSurface = errors.New("no surface to present to")
+
+// ErrPresentationNotSupported is returned by CreateSwapchain when the device
+// queue family can't present to the surface or VK_KHR_swapchain is not
+// enabled.
+var ErrPresentationNotSupported = errors.New("device can't present to the surface")
+
+// SetSurface makes v present to surface. v takes ownership of the surface
+// and destroys it in Destroy, along with any surface set before.
+func (v *VulkanDeviceInfo) SetSurface(surface vk.Surface) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.surface != vk.NullSurface && v.surface != surface {
+		vk.DestroySurface(v.instance, v.surface, v.allocator)
+	}
+	v.surface = surface
+}
+
+// VulkanSwapchainInfo holds a swapchain created by CreateSwapchain, its
+// images and a color view of each image.
+type VulkanSwapchainInfo struct {
+	Swapchain   vk.Swapchain
+	Images      []vk.Image
+	ImageViews  []vk.ImageView
+	Format      vk.SurfaceFormat
+	Extent      vk.Extent2D
+	PresentMode vk.PresentMode
+
+	device    vk.Device
+	allocator *vk.AllocationCallbacks
+}
+
+// swapchainConfig collects the settings applied by SwapchainOptions.
+type swapchainConfig struct {
+	presentMode    vk.PresentMode
+	hasPresentMode bool
+}
+
+// SwapchainOption customizes how CreateSwapchain creates the swapchain.
+type SwapchainOption func(*swapchainConfig)
+
+// WithPresentMode makes CreateSwapchain use mode if the surface supports
+// it. The default, and the fallback, is vk.PresentModeFifo.
+func WithPresentMode(mode vk.PresentMode) SwapchainOption {
+	return func(c *swapchainConfig) {
+		c.presentMode = mode
+		c.hasPresentMode = true
+	}
+}
+
+// CreateSwapchain creates a swapchain for the surface set with SetSurface.
+// width and height are used when the surface lets the swapchain pick its
+// extent; otherwise the surface's current extent is used.
+func CreateSwapchain(v *VulkanDeviceInfo, width, height uint32, opts ...SwapchainOption) (*VulkanSwapchainInfo, error) {
+	config := &swapchainConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if v.surface == vk.NullSurface {
+		return nil, ErrNoSurface
+	}
+	if v.device == nil || !v.presentationEnabled {
+		return nil, ErrPresentationNotSupported
+	}
+	gpu := v.gpuDevices[v.gpuIndex]
+	var supported vk.Bool32
+	err := vk.Error(vk.GetPhysicalDeviceSurfaceSupport(gpu, v.queueFamilyIndex, v.surface, &supported))
+	if err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceSurfaceSupportKHR failed with %s", err)
+		return nil, err
+	}
+	if !supported.B() {
+		return nil, ErrPresentationNotSupported
+	}
+
+	capabilities, err := GetSurfaceCapabilities(v, v.surface)
+	if err != nil {
+		return nil, err
+	}
+	formats, err := GetSurfaceFormats(v, v.surface)
+	if err != nil {
+		return nil, err
+	}
+	presentModes, err := GetPresentModes(v, v.surface)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &VulkanSwapchainInfo{
+		Format:      chooseSurfaceFormat(formats),
+		Extent:      chooseSwapchainExtent(capabilities, width, height),
+		PresentMode: vk.PresentModeFifo,
+		device:      v.device,
+		allocator:   v.allocator,
+	}
+	if config.hasPresentMode {
+		for _, mode := range presentModes {
+			if mode == config.presentMode {
+				s.PresentMode = mode
+			}
+		}
+	}
+	imageCount := capabilities.MinImageCount + 1
+	if capabilities.MaxImageCount > 0 && imageCount > capabilities.MaxImageCount {
+		imageCount = capabilities.MaxImageCount
+	}
+	createInfo := &vk.SwapchainCreateInfo{
+		SType:            vk.StructureTypeSwapchainCreateInfo,
+		Surface:          v.surface,
+		MinImageCount:    imageCount,
+		ImageFormat:      s.Format.Format,
+		ImageColorSpace:  s.Format.ColorSpace,
+		ImageExtent:      s.Extent,
+		ImageArrayLayers: 1,
+		ImageUsage:       vk.ImageUsageFlags(vk.ImageUsageColorAttachmentBit),
+		ImageSharingMode: vk.SharingModeExclusive,
+		PreTransform:     capabilities.CurrentTransform,
+		CompositeAlpha:   chooseCompositeAlpha(capabilities.SupportedCompositeAlpha),
+		PresentMode:      s.PresentMode,
+		Clipped:          vk.True,
+		OldSwapchain:     vk.NullSwapchain,
+	}
+	err = vk.Error(vk.CreateSwapchain(v.device, createInfo, v.allocator, &s.Swapchain))
+	if err != nil {
+		err = fmt.Errorf("vkCreateSwapchainKHR failed with %s", err)
+		return nil, err
+	}
+
+	var count uint32
+	err = vk.Error(vk.GetSwapchainImages(v.device, s.Swapchain, &count, nil))
+	if err != nil {
+		s.Destroy()
+		err = fmt.Errorf("vkGetSwapchainImagesKHR failed with %s", err)
+		return nil, err
+	}
+	s.Images = make([]vk.Image, count)
+	err = vk.Error(vk.GetSwapchainImages(v.device, s.Swapchain, &count, s.Images))
+	if err != nil {
+		s.Destroy()
+		err = fmt.Errorf("vkGetSwapchainImagesKHR failed with %s", err)
+		return nil, err
+	}
+	for _, image := range s.Images {
+		viewCreateInfo := &vk.ImageViewCreateInfo{
+			SType:    vk.StructureTypeImageViewCreateInfo,
+			Image:    image,
+			ViewType: vk.ImageViewType2d,
+			Format:   s.Format.Format,
+			Components: vk.ComponentMapping{
+				R: vk.ComponentSwizzleIdentity,
+				G: vk.ComponentSwizzleIdentity,
+				B: vk.ComponentSwizzleIdentity,
+				A: vk.ComponentSwizzleIdentity,
+			},
+			SubresourceRange: vk.ImageSubresourceRange{
+				AspectMask: vk.ImageAspectFlags(vk.ImageAspectColorBit),
+				LevelCount: 1,
+				LayerCount: 1,
+			},
+		}
+		var view vk.ImageView
+		err = vk.Error(vk.CreateImageView(v.device, viewCreateInfo, v.allocator, &view))
+		if err != nil {
+			s.Destroy()
+			err = fmt.Errorf("vkCreateImageView failed with %s", err)
+			return nil, err
+		}
+		s.ImageViews = append(s.ImageViews, view)
+	}
+	return s, nil
+}
+
+// Destroy releases the image views and the swapchain. Calling it again is
+// a no-op. The device must not be using the swapchain images.
+func (s *VulkanSwapchainInfo) Destroy() {
+	if s == nil {
+		return
+	}
+	for _, view := range s.ImageViews {
+		vk.DestroyImageView(s.device, view, s.allocator)
+	}
+	s.ImageViews = nil
+	s.Images = nil
+	if s.Swapchain != vk.NullSwapchain {
+		vk.DestroySwapchain(s.device, s.Swapchain, s.allocator)
+		s.Swapchain = vk.NullSwapchain
+	}
+}
+
+// chooseSurfaceFormat prefers 8-bit BGRA in the sRGB color space, falling
+// back to the first format the surface reports.
+func chooseSurfaceFormat(formats []vk.SurfaceFormat) vk.SurfaceFormat {
+	preferred := vk.SurfaceFormat{
+		Format:     vk.FormatB8g8r8a8Unorm,
+		ColorSpace: vk.ColorSpaceSrgbNonlinear,
+	}
+	if len(formats) == 1 && formats[0].Format == vk.FormatUndefined {
+		return preferred
+	}
+	for _, format := range formats {
+		if format.Format == preferred.Format && format.ColorSpace == preferred.ColorSpace {
+			return format
+		}
+	}
+	return formats[0]
+}
+
+// chooseSwapchainExtent returns the surface's current extent, or width and
+// height clamped to the supported range if the surface leaves it to the
+// swapchain.
+func chooseSwapchainExtent(capabilities vk.SurfaceCapabilities, width, height uint32) vk.Extent2D {
+	if capabilities.CurrentExtent.Width != vk.MaxUint32 {
+		return capabilities.CurrentExtent
+	}
+	return vk.Extent2D{
+		Width:  clampUint32(width, capabilities.MinImageExtent.Width, capabilities.MaxImageExtent.Width),
+		Height: clampUint32(height, capabilities.MinImageExtent.Height, capabilities.MaxImageExtent.Height),
+	}
+}
+
+// chooseCompositeAlpha prefers opaque composition, falling back to the
+// first mode the surface supports.
+func chooseCompositeAlpha(supported vk.CompositeAlphaFlags) vk.CompositeAlphaFlagBits {
+	modes := []vk.CompositeAlphaFlagBits{
+		vk.CompositeAlphaOpaqueBit,
+		vk.CompositeAlphaPreMultipliedBit,
+		vk.CompositeAlphaPostMultipliedBit,
+		vk.CompositeAlphaInheritBit,
+	}
+	for _, mode := range modes {
+		if supported&vk.CompositeAlphaFlags(mode) != 0 {
+			return mode
+		}
+	}
+	return vk.CompositeAlphaOpaqueBit
+}
+
+func clampUint32(value, min, max uint32) uint32 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
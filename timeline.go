@@ -0,0 +1,247 @@
+package vulkandevice
+
+/*
+#include <stdint.h>
+#include <stddef.h>
+#include <stdlib.h>
+
+// lookup_instance_proc is defined in proc.go.
+extern void *lookup_instance_proc(void *instance, const char *name);
+
+// semaphore_signal_info has the layout of VkSemaphoreSignalInfo.
+typedef struct {
+	int32_t sType;
+	const void *pNext;
+	uint64_t semaphore;
+	uint64_t value;
+} semaphore_signal_info;
+
+// semaphore_wait_info has the layout of VkSemaphoreWaitInfo.
+typedef struct {
+	int32_t sType;
+	const void *pNext;
+	uint32_t flags;
+	uint32_t semaphoreCount;
+	const uint64_t *pSemaphores;
+	const uint64_t *pValues;
+} semaphore_wait_info;
+
+typedef int32_t (*signal_semaphore_t)(void *device, const semaphore_signal_info *signalInfo);
+typedef int32_t (*wait_semaphores_t)(void *device, const semaphore_wait_info *waitInfo, uint64_t timeout);
+
+static int32_t signal_semaphore(void *instance, const char *name, void *device, uint64_t semaphore,
+	uint64_t value, int *found) {
+	signal_semaphore_t fn = (signal_semaphore_t)lookup_instance_proc(instance, name);
+	*found = fn != NULL;
+	if (fn == NULL) {
+		return 0;
+	}
+	semaphore_signal_info signalInfo = {
+		1000207005, // VK_STRUCTURE_TYPE_SEMAPHORE_SIGNAL_INFO
+		NULL,
+		semaphore,
+		value,
+	};
+	return fn(device, &signalInfo);
+}
+
+static int32_t wait_semaphore(void *instance, const char *name, void *device, uint64_t semaphore,
+	uint64_t value, uint64_t timeout, int *found) {
+	wait_semaphores_t fn = (wait_semaphores_t)lookup_instance_proc(instance, name);
+	*found = fn != NULL;
+	if (fn == NULL) {
+		return 0;
+	}
+	semaphore_wait_info waitInfo = {
+		1000207004, // VK_STRUCTURE_TYPE_SEMAPHORE_WAIT_INFO
+		NULL,
+		0,
+		1,
+		&semaphore,
+		&value,
+	};
+	return fn(device, &waitInfo, timeout);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// timelineSemaphoreExtensionName provides timeline semaphores on devices
+// older than Vulkan 1.2.
+const timelineSemaphoreExtensionName = "VK_KHR_timeline_semaphore"
+
+// Structure types of the timeline semaphore structures, which are newer
+// than the vulkan-go bindings.
+const (
+	structureTypePhysicalDeviceTimelineSemaphoreFeatures vk.StructureType = 1000207000
+	structureTypeSemaphoreTypeCreateInfo                 vk.StructureType = 1000207002
+)
+
+// semaphoreTypeTimeline is VK_SEMAPHORE_TYPE_TIMELINE.
+const semaphoreTypeTimeline = 1
+
+// physicalDeviceTimelineSemaphoreFeatures has the C layout of
+// VkPhysicalDeviceTimelineSemaphoreFeatures.
+type physicalDeviceTimelineSemaphoreFeatures struct {
+	chainHeader
+	TimelineSemaphore vk.Bool32
+}
+
+// semaphoreTypeCreateInfo has the C layout of VkSemaphoreTypeCreateInfo.
+type semaphoreTypeCreateInfo struct {
+	chainHeader
+	SemaphoreType int32
+	InitialValue  uint64
+}
+
+// ErrFeatureNotEnabled is returned when a function needs a device feature
+// the logical device wasn't created with.
+var ErrFeatureNotEnabled = errors.New("device feature not enabled")
+
+// ErrFeatureNotSupported is returned by NewVulkanDevice when an option asks
+// for a device feature the GPU doesn't support.
+var ErrFeatureNotSupported = errors.New("device feature not supported")
+
+// WithTimelineSemaphores enables the timelineSemaphore feature on the
+// logical device, through VK_KHR_timeline_semaphore on GPUs older than
+// Vulkan 1.2, for CreateTimelineSemaphore. NewVulkanDevice fails with
+// ErrFeatureNotSupported if the GPU lacks it.
+func WithTimelineSemaphores() Option {
+	return func(c *deviceConfig) {
+		c.timelineSemaphores = true
+	}
+}
+
+// timelineSemaphoreFeatures checks that the GPU at gpuIndex supports
+// timeline semaphores and returns the features structure enabling them at
+// device creation, along with the extension to enable, if any.
+func timelineSemaphoreFeatures(v *VulkanDeviceInfo, gpuIndex int, extensions []vk.ExtensionProperties) (physicalDeviceTimelineSemaphoreFeatures, string, error) {
+	features := physicalDeviceTimelineSemaphoreFeatures{chainHeader: chainHeader{SType: structureTypePhysicalDeviceTimelineSemaphoreFeatures}}
+	var extension string
+	if getDeviceProperties(v.gpuDevices[gpuIndex]).ApiVersion < vk.MakeVersion(1, 2, 0) {
+		if !hasExtension(extensions, timelineSemaphoreExtensionName) {
+			err := fmt.Errorf("%w: %s", ErrExtensionNotSupported, timelineSemaphoreExtensionName)
+			return features, "", err
+		}
+		extension = timelineSemaphoreExtensionName
+	}
+	_, err := GetPhysicalDeviceFeatures2(v, gpuIndex, ChainedStruct{unsafe.Pointer(&features), unsafe.Sizeof(features)})
+	if err != nil {
+		return features, "", err
+	}
+	if !features.TimelineSemaphore.B() {
+		err := fmt.Errorf("%w: timelineSemaphore", ErrFeatureNotSupported)
+		return features, "", err
+	}
+	return features, extension, nil
+}
+
+// CreateTimelineSemaphore creates a timeline semaphore with the given
+// initial value. The device must have been created with
+// WithTimelineSemaphores, otherwise it fails with ErrFeatureNotEnabled.
+// Destroy the semaphore with vk.DestroySemaphore.
+func CreateTimelineSemaphore(v *VulkanDeviceInfo, initialValue uint64) (vk.Semaphore, error) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if !v.timelineSemaphores {
+		err := fmt.Errorf("%w: timelineSemaphore", ErrFeatureNotEnabled)
+		return vk.NullSemaphore, err
+	}
+	typeInfo := semaphoreTypeCreateInfo{
+		chainHeader:   chainHeader{SType: structureTypeSemaphoreTypeCreateInfo},
+		SemaphoreType: semaphoreTypeTimeline,
+		InitialValue:  initialValue,
+	}
+	var chain structChain
+	chain.add(unsafe.Pointer(&typeInfo), unsafe.Sizeof(typeInfo))
+	defer chain.free()
+	createInfo := &vk.SemaphoreCreateInfo{
+		SType: vk.StructureTypeSemaphoreCreateInfo,
+		PNext: chain.link(),
+	}
+	var semaphore vk.Semaphore
+	if err := vk.Error(vk.CreateSemaphore(v.device, createInfo, v.allocator, &semaphore)); err != nil {
+		err = fmt.Errorf("vkCreateSemaphore failed with %s", err)
+		return vk.NullSemaphore, err
+	}
+	return semaphore, nil
+}
+
+// SignalSemaphore sets the counter of the timeline semaphore sem to value
+// from the host.
+func SignalSemaphore(v *VulkanDeviceInfo, sem vk.Semaphore, value uint64) error {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if !v.timelineSemaphores {
+		err := fmt.Errorf("%w: timelineSemaphore", ErrFeatureNotEnabled)
+		return err
+	}
+	name := C.CString(v.timelineSemaphoreName("vkSignalSemaphore"))
+	defer C.free(unsafe.Pointer(name))
+	var found C.int
+	ret := vk.Result(C.signal_semaphore(unsafe.Pointer(v.instance), name, unsafe.Pointer(v.device),
+		C.uint64_t(semaphoreHandle(sem)), C.uint64_t(value), &found))
+	if found == 0 {
+		err := fmt.Errorf("vkSignalSemaphore not available")
+		return err
+	}
+	if err := vk.Error(ret); err != nil {
+		err = fmt.Errorf("vkSignalSemaphore failed with %s", err)
+		return err
+	}
+	return nil
+}
+
+// WaitSemaphore waits until the counter of the timeline semaphore sem
+// reaches value. It returns an error wrapping ErrTimeout if timeoutNs
+// nanoseconds pass first.
+func WaitSemaphore(v *VulkanDeviceInfo, sem vk.Semaphore, value uint64, timeoutNs uint64) error {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if !v.timelineSemaphores {
+		err := fmt.Errorf("%w: timelineSemaphore", ErrFeatureNotEnabled)
+		return err
+	}
+	name := C.CString(v.timelineSemaphoreName("vkWaitSemaphores"))
+	defer C.free(unsafe.Pointer(name))
+	var found C.int
+	ret := vk.Result(C.wait_semaphore(unsafe.Pointer(v.instance), name, unsafe.Pointer(v.device),
+		C.uint64_t(semaphoreHandle(sem)), C.uint64_t(value), C.uint64_t(timeoutNs), &found))
+	if found == 0 {
+		err := fmt.Errorf("vkWaitSemaphores not available")
+		return err
+	}
+	if ret == vk.Timeout {
+		err := fmt.Errorf("%w: vkWaitSemaphores after %dns", ErrTimeout, timeoutNs)
+		return err
+	}
+	if err := vk.Error(ret); err != nil {
+		err = fmt.Errorf("vkWaitSemaphores failed with %s", err)
+		return err
+	}
+	return nil
+}
+
+// timelineSemaphoreName returns the name of the timeline semaphore entry
+// point to use, with the KHR suffix when the device uses
+// VK_KHR_timeline_semaphore.
+func (v *VulkanDeviceInfo) timelineSemaphoreName(name string) string {
+	if v.timelineSemaphoresKHR {
+		return name + "KHR"
+	}
+	return name
+}
+
+// semaphoreHandle returns the 64-bit handle value of sem. Non-dispatchable
+// handles are pointers in the bindings on 64-bit platforms and uint64 on
+// 32-bit ones, 8 bytes either way.
+func semaphoreHandle(sem vk.Semaphore) uint64 {
+	return *(*uint64)(unsafe.Pointer(&sem))
+}
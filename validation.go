@@ -0,0 +1,68 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+const validationLayerName = "VK_LAYER_KHRONOS_validation"
+
+// ErrValidationLayerNotAvailable is returned by NewVulkanDevice when
+// validation was requested but VK_LAYER_KHRONOS_validation is not installed.
+var ErrValidationLayerNotAvailable = errors.New("validation layer " + validationLayerName + " is not available")
+
+// LogFunc receives messages reported through VK_EXT_debug_report.
+type LogFunc func(flags vk.DebugReportFlags, msg string)
+
+// WithValidationLayers enables VK_LAYER_KHRONOS_validation on the instance.
+func WithValidationLayers() Option {
+	return func(c *deviceConfig) {
+		c.validation = true
+	}
+}
+
+// WithDebugLog routes VK_EXT_debug_report messages to logFunc.
+func WithDebugLog(logFunc LogFunc) Option {
+	return func(c *deviceConfig) {
+		c.logFunc = logFunc
+	}
+}
+
+func createDebugReportCallback(instance vk.Instance, logFunc LogFunc, allocator *vk.AllocationCallbacks) (vk.DebugReportCallback, error) {
+	createInfo := &vk.DebugReportCallbackCreateInfo{
+		SType: vk.StructureTypeDebugReportCallbackCreateInfo,
+		Flags: vk.DebugReportFlags(vk.DebugReportErrorBit | vk.DebugReportWarningBit |
+			vk.DebugReportPerformanceWarningBit),
+		PfnCallback: func(flags vk.DebugReportFlags, objectType vk.DebugReportObjectType,
+			object uint64, location uint, messageCode int32, pLayerPrefix string,
+			pMessage string, pUserData unsafe.Pointer) vk.Bool32 {
+
+			logFunc(flags, fmt.Sprintf("[%s] %s", pLayerPrefix, pMessage))
+			return vk.False
+		},
+	}
+	var callback vk.DebugReportCallback
+	err := vk.Error(vk.CreateDebugReportCallback(instance, createInfo, allocator, &callback))
+	if err != nil {
+		err = fmt.Errorf("vkCreateDebugReportCallbackEXT failed with %s", err)
+		return vk.NullDebugReportCallback, err
+	}
+	return callback, nil
+}
+
+var debugReportFlagTable = []flagName{
+	{uint32(vk.DebugReportErrorBit), "ERROR"},
+	{uint32(vk.DebugReportWarningBit), "WARNING"},
+	{uint32(vk.DebugReportPerformanceWarningBit), "PERFORMANCE"},
+	{uint32(vk.DebugReportInformationBit), "INFO"},
+	{uint32(vk.DebugReportDebugBit), "DEBUG"},
+}
+
+// DebugReportFlagNames renders flags as "ERROR|WARNING", for LogFunc
+// implementations.
+func DebugReportFlagNames(flags vk.DebugReportFlags) string {
+	return joinFlags(flagNames(uint32(flags), debugReportFlagTable))
+}
@@ -0,0 +1,48 @@
+package vulkandevice
+
+import "fmt"
+
+// PCI vendor IDs of common GPU vendors, for use with WithVendorID.
+const (
+	VendorIDNVIDIA   uint32 = 0x10de
+	VendorIDAMD      uint32 = 0x1002
+	VendorIDIntel    uint32 = 0x8086
+	VendorIDARM      uint32 = 0x13b5
+	VendorIDQualcomm uint32 = 0x5143
+)
+
+// vendorNames maps PCI vendor IDs, and the Khronos vendor IDs used by
+// implementations without one, to vendor names.
+var vendorNames = map[uint32]string{
+	VendorIDAMD:      "AMD",
+	0x1010:           "Imagination",
+	0x106b:           "Apple",
+	VendorIDNVIDIA:   "NVIDIA",
+	VendorIDARM:      "ARM",
+	0x1414:           "Microsoft",
+	0x144d:           "Samsung",
+	0x14e4:           "Broadcom",
+	VendorIDQualcomm: "Qualcomm",
+	VendorIDIntel:    "Intel",
+	0x10001:          "Vivante",
+	0x10002:          "VeriSilicon",
+	0x10003:          "Kazan",
+	0x10004:          "Codeplay",
+	0x10005:          "Mesa",
+	0x10006:          "PoCL",
+}
+
+// VendorName returns the name of the vendor with the given ID, or an empty
+// string if the ID is unknown.
+func VendorName(vendorID uint32) string {
+	return vendorNames[vendorID]
+}
+
+// formatVendor renders a vendor ID as "NVIDIA (0x10de)", or as bare hex if
+// the vendor is unknown.
+func formatVendor(vendorID uint32) string {
+	if name := VendorName(vendorID); name != "" {
+		return fmt.Sprintf("%s (0x%x)", name, vendorID)
+	}
+	return fmt.Sprintf("0x%x", vendorID)
+}
@@ -0,0 +1,32 @@
+package vulkandevice
+
+import "testing"
+
+func TestVendorName(t *testing.T) {
+	tests := []struct {
+		vendorID uint32
+		name     string
+		format   string
+	}{
+		{VendorIDNVIDIA, "NVIDIA", "NVIDIA (0x10de)"},
+		{VendorIDAMD, "AMD", "AMD (0x1002)"},
+		{VendorIDIntel, "Intel", "Intel (0x8086)"},
+		{VendorIDARM, "ARM", "ARM (0x13b5)"},
+		{VendorIDQualcomm, "Qualcomm", "Qualcomm (0x5143)"},
+		{0x106b, "Apple", "Apple (0x106b)"},
+		// Khronos vendor IDs of implementations without a PCI vendor ID.
+		{0x10003, "Kazan", "Kazan (0x10003)"},
+		{0x10005, "Mesa", "Mesa (0x10005)"},
+		{0x10006, "PoCL", "PoCL (0x10006)"},
+		{0xbeef, "", "0xbeef"},
+		{0, "", "0x0"},
+	}
+	for _, tt := range tests {
+		if got := VendorName(tt.vendorID); got != tt.name {
+			t.Errorf("VendorName(%#x) = %q, want %q", tt.vendorID, got, tt.name)
+		}
+		if got := formatVendor(tt.vendorID); got != tt.format {
+			t.Errorf("formatVendor(%#x) = %q, want %q", tt.vendorID, got, tt.format)
+		}
+	}
+}
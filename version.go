@@ -0,0 +1,74 @@
+package vulkandevice
+
+import (
+	"errors"
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// maxAPIVersion is the highest instance API version this package knows the
+// structures of, either from the vulkan-go bindings or declared in Go.
+var maxAPIVersion = vk.MakeVersion(1, 3, 0)
+
+// EnumerateInstanceVersion returns the instance API version supported by the
+// Vulkan loader. Loaders that predate vkEnumerateInstanceVersion only
+// support Vulkan 1.0, so 1.0.0 is returned for them.
+func EnumerateInstanceVersion() (uint32, error) {
+	version, ret, found := enumerateInstanceVersion()
+	if !found {
+		return vk.MakeVersion(1, 0, 0), nil
+	}
+	if err := vk.Error(ret); err != nil {
+		err = fmt.Errorf("vkEnumerateInstanceVersion failed with %s", err)
+		return 0, err
+	}
+	return version, nil
+}
+
+// negotiateAPIVersion returns the highest instance API version supported by
+// both the loader and this package.
+func negotiateAPIVersion(loaderVersion uint32) uint32 {
+	if loaderVersion > maxAPIVersion {
+		return maxAPIVersion
+	}
+	return loaderVersion
+}
+
+// ErrAPIVersionTooOld is returned by RequireAPIVersion when the selected GPU
+// supports an older Vulkan version than required.
+var ErrAPIVersionTooOld = errors.New("Vulkan API version too old")
+
+// ErrDeviceDestroyed is returned by methods needing the GPUs after Destroy,
+// or after Recreate failed.
+var ErrDeviceDestroyed = errors.New("device was destroyed")
+
+// GetAPIVersion returns the Vulkan version supported by the selected GPU,
+// or zeros after Destroy or a failed Recreate.
+func (v *VulkanDeviceInfo) GetAPIVersion() (major, minor, patch uint32) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if len(v.gpuDevices) == 0 {
+		return 0, 0, 0
+	}
+	version := vk.Version(getDeviceProperties(v.gpuDevices[v.gpuIndex]).ApiVersion)
+	return uint32(version.Major()), uint32(version.Minor()), uint32(version.Patch())
+}
+
+// RequireAPIVersion returns ErrAPIVersionTooOld if the selected GPU supports
+// a Vulkan version older than major.minor.patch, and ErrDeviceDestroyed
+// after Destroy or a failed Recreate.
+func (v *VulkanDeviceInfo) RequireAPIVersion(major, minor, patch uint32) error {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if len(v.gpuDevices) == 0 {
+		return ErrDeviceDestroyed
+	}
+	have := getDeviceProperties(v.gpuDevices[v.gpuIndex]).ApiVersion
+	need := vk.MakeVersion(int(major), int(minor), int(patch))
+	if have < need {
+		err := fmt.Errorf("%w: device supports %s, need %s", ErrAPIVersionTooOld, vk.Version(have), vk.Version(need))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package vulkandevice
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAPIVersionAfterDestroy(t *testing.T) {
+	v := &VulkanDeviceInfo{}
+	v.Destroy()
+	if major, minor, patch := v.GetAPIVersion(); major != 0 || minor != 0 || patch != 0 {
+		t.Errorf("GetAPIVersion() = %d.%d.%d, want 0.0.0", major, minor, patch)
+	}
+	if err := v.RequireAPIVersion(1, 0, 0); !errors.Is(err, ErrDeviceDestroyed) {
+		t.Errorf("RequireAPIVersion() = %v, want ErrDeviceDestroyed", err)
+	}
+}
@@ -0,0 +1,154 @@
+package vulkandevice
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// The report types are encoded to YAML through their JSON form, so YAML keys
+// are the same snake_case names as the JSON ones, in the same order, without
+// a second set of struct tags to keep in sync.
+
+// MarshalYAML implements yaml.Marshaler.
+func (r Report) MarshalYAML() (interface{}, error) {
+	return jsonToYAMLNode(r)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (r *Report) UnmarshalYAML(node *yaml.Node) error {
+	return yamlNodeToJSON(node, r)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (d DeviceReport) MarshalYAML() (interface{}, error) {
+	return jsonToYAMLNode(d)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (d *DeviceReport) UnmarshalYAML(node *yaml.Node) error {
+	return yamlNodeToJSON(node, d)
+}
+
+// PrintYAML writes the GatherDeviceReport report to w as YAML.
+func PrintYAML(w io.Writer, v *VulkanDeviceInfo) error {
+	report, err := GatherDeviceReport(v)
+	if err != nil {
+		return err
+	}
+	out, err := marshalYAML(report)
+	if err != nil {
+		err = fmt.Errorf("PrintYAML: %s", err)
+		return err
+	}
+	fmt.Fprint(w, string(out))
+	return nil
+}
+
+// DeviceInfoYAML returns the YAML document describing the GPU at gpuIndex.
+func DeviceInfoYAML(v *VulkanDeviceInfo, gpuIndex int) ([]byte, error) {
+	if gpuIndex < 0 || gpuIndex >= len(v.gpuDevices) {
+		err := fmt.Errorf("DeviceInfoYAML: GPU index %d out of range", gpuIndex)
+		return nil, err
+	}
+	out, err := marshalYAML(newDeviceReport(v, gpuIndex))
+	if err != nil {
+		err = fmt.Errorf("DeviceInfoYAML: %s", err)
+		return nil, err
+	}
+	return out, nil
+}
+
+// marshalYAML encodes value with the two-space indentation of the JSON
+// output.
+func marshalYAML(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(value); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonToYAMLNode encodes value as JSON and converts the document to a YAML
+// node tree, keeping the key order and tagging numbers explicitly so
+// numeric-looking strings stay quoted.
+func jsonToYAMLNode(value interface{}) (*yaml.Node, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return jsonValueNode(dec)
+}
+
+func jsonValueNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch t := tok.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		if t == '[' {
+			node = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		}
+		for dec.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, scalarNode("!!str", key.(string)))
+			}
+			value, err := jsonValueNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, value)
+		}
+		// Consume the closing delimiter.
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return node, nil
+	case json.Number:
+		if strings.ContainsAny(t.String(), ".eE") {
+			return scalarNode("!!float", t.String()), nil
+		}
+		return scalarNode("!!int", t.String()), nil
+	case string:
+		return scalarNode("!!str", t), nil
+	case bool:
+		return scalarNode("!!bool", fmt.Sprint(t)), nil
+	default:
+		return scalarNode("!!null", "null"), nil
+	}
+}
+
+func scalarNode(tag, value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+}
+
+// yamlNodeToJSON decodes node generically and unmarshals its JSON encoding
+// into out, so the JSON tags of out apply.
+func yamlNodeToJSON(node *yaml.Node, out interface{}) error {
+	var generic interface{}
+	if err := node.Decode(&generic); err != nil {
+		return err
+	}
+	data, err := json.Marshal(generic)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
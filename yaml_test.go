@@ -0,0 +1,101 @@
+package vulkandevice
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestReportYAMLRoundTrip(t *testing.T) {
+	budget, usage := uint64(20<<30), uint64(0)
+	want := Report{
+		GPUCount:           1,
+		LoaderVersion:      newVersion(1<<22 | 3<<12 | 275),
+		InstanceAPIVersion: newVersion(1<<22 | 3<<12),
+		InstanceLayers: []Layer{{
+			Name:                  "VK_LAYER_KHRONOS_validation",
+			SpecVersion:           newVersion(1<<22 | 3<<12 | 275),
+			ImplementationVersion: 1,
+			Description:           "Khronos Validation Layer: yes, no, 1.0",
+		}},
+		InstanceExtensions: []Extension{{Name: "VK_KHR_surface", SpecVersion: 25}},
+		Devices: []DeviceReport{{
+			Index:         0,
+			Name:          "Test GPU",
+			VendorID:      0x10de,
+			VendorName:    "NVIDIA",
+			DeviceType:    "Discrete GPU",
+			APIVersion:    newVersion(1<<22 | 3<<12 | 260),
+			DriverVersion: newDriverVersion(0x10de, 0x86a00000),
+			// Numeric-looking strings must stay strings.
+			PipelineCacheUUID: "12345678",
+			DeviceUUID:        "0e+10",
+			DriverInfo:        "1.0",
+			PCIBusInfo:        &PCIBusInfo{Domain: 0, Bus: 0x65, Address: "0000:65:00.0"},
+			Limits: Limits{
+				MaxImageDimension2D:     32768,
+				BufferImageGranularity:  1024,
+				SparseAddressSpaceSize:  math.MaxUint64,
+				MaxSamplerAnisotropy:    16,
+				MaxSamplerLodBias:       15.99,
+				ViewportBoundsRange:     [2]float32{-65536, 65535.5},
+				MaxComputeWorkGroupSize: [3]uint32{1024, 1024, 64},
+			},
+			Features: map[string]bool{
+				"samplerAnisotropy": true,
+				"geometryShader":    false,
+				"123":               true,
+				"true":              false,
+			},
+			QueueFamilies: []QueueFamily{
+				{Index: 0, QueueCount: 16, Flags: []string{"GRAPHICS", "COMPUTE", "TRANSFER"}, TimestampValidBits: 64,
+					MinImageTransferGranularity: Extent3D{Width: 1, Height: 1, Depth: 1}},
+				{Index: 1, QueueCount: 2, Flags: []string{"TRANSFER"}},
+			},
+			MemoryHeaps: []MemoryHeap{
+				{Index: 0, Size: 24 << 30, Budget: &budget, Usage: &usage, Flags: []string{"DEVICE_LOCAL"}},
+				{Index: 1, Size: 64 << 30, Flags: []string{}},
+			},
+			MemoryTypes: []MemoryType{{Index: 0, HeapIndex: 0, Flags: []string{"DEVICE_LOCAL"}}},
+			Extensions: []Extension{
+				{Name: "VK_KHR_swapchain", SpecVersion: 70},
+				{Name: "VK_KHR_ray_query", SpecVersion: 1},
+			},
+			TextureCompression: TextureCompression{BC: true},
+			DriverName:         "NVIDIA",
+			ConformanceVersion: "1.3.5.0",
+			Subgroup:           &SubgroupInfo{SubgroupSize: 32, SupportedStages: "COMPUTE", SupportedOperations: "BASIC|VOTE"},
+		}},
+		SelectedGPU: 0,
+	}
+
+	out, err := marshalYAML(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `pipeline_cache_uuid: "12345678"`) {
+		t.Errorf("numeric-looking string not quoted in:\n%s", out)
+	}
+	var got Report
+	if err := yaml.Unmarshal(out, &got); err != nil {
+		t.Fatalf("yaml.Unmarshal: %s\n%s", err, out)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip mismatch\ngot:  %+v\nwant: %+v\nYAML:\n%s", got, want, out)
+	}
+}
+
+func TestDeviceReportYAMLKeyOrder(t *testing.T) {
+	out, err := marshalYAML(DeviceReport{Name: "GPU"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	index := strings.Index(string(out), "index:")
+	name := strings.Index(string(out), "name:")
+	if index < 0 || name < 0 || index > name {
+		t.Errorf("keys not in JSON field order:\n%s", out)
+	}
+}